@@ -0,0 +1,97 @@
+/** Constantine
+ *  Copyright (c) 2018-2019    Status Research & Development GmbH
+ *  Copyright (c) 2020-Present Mamy André-Ratsimbazafy
+ *  Licensed and distributed under either of
+ *    * MIT license (license terms in the root directory or at http://opensource.org/licenses/MIT).
+ *    * Apache v2 license (license terms in the root directory or at http://www.apache.org/licenses/LICENSE-2.0).
+ *  at your option. This file may not be copied, modified, or distributed except according to those terms.
+ */
+
+package constantine
+
+import (
+	"math/big"
+
+	"github.com/mratsim/constantine/constantine-go/sha256"
+)
+
+// Deterministic batch-verification randomness
+// -----------------------------------------------------
+//
+// VerifyBlobKzgProofBatch takes a caller-supplied secureRandomBytes, which
+// callers typically fill from crypto/rand. That makes the result
+// non-reproducible and hard to audit across nodes. KzgBatchTranscript
+// derives that randomness instead via a Fiat-Shamir transcript, so two
+// honest nodes batch-verifying the same inputs always agree on `r`.
+
+const kzgBatchDomainTag = "CONSTANTINE_KZG_BATCH_V1"
+
+// bls12381ScalarFieldOrder is the order r of the BLS12-381 scalar field.
+var bls12381ScalarFieldOrder, _ = new(big.Int).SetString(
+	"52435875175126190479447740508185965837690552500527637822603658699938581184513", 10,
+)
+
+// KzgBatchTranscript accumulates a Fiat-Shamir transcript for
+// VerifyBlobKzgProofBatchDeterministic. Call Bind before Challenge to tie
+// the batch to external context, e.g. a slot number or block root, so a
+// verifier can't replay a batch's randomness against a different context.
+type KzgBatchTranscript struct {
+	buf []byte
+}
+
+func NewKzgBatchTranscript() *KzgBatchTranscript {
+	return &KzgBatchTranscript{buf: []byte(kzgBatchDomainTag)}
+}
+
+// Bind appends extra domain-separation context to the transcript.
+func (t *KzgBatchTranscript) Bind(context []byte) {
+	t.buf = append(t.buf, context...)
+}
+
+// AppendBatch feeds a batch's commitments, proofs, and blobs into the
+// transcript. Callers building their own KzgBatchTranscript (e.g. to Bind
+// a slot number before hashing) call this themselves; the Deterministic
+// verification methods below do so internally.
+func (t *KzgBatchTranscript) AppendBatch(blobs []EthBlob, commitments []EthKzgCommitment, proofs []EthKzgProof) {
+	for _, c := range commitments {
+		t.buf = append(t.buf, c[:]...)
+	}
+	for _, p := range proofs {
+		t.buf = append(t.buf, p[:]...)
+	}
+	for _, b := range blobs {
+		digest := sha256.Hash(b[:], false)
+		t.buf = append(t.buf, digest[:]...)
+	}
+}
+
+// Challenge hashes the accumulated transcript with SHA256 and reduces the
+// digest modulo the BLS12-381 scalar field order to produce the `r` used
+// for the random linear combination in VerifyBlobKzgProofBatch.
+func (t *KzgBatchTranscript) Challenge() (r [32]byte) {
+	digest := sha256.Hash(t.buf, false)
+	v := new(big.Int).SetBytes(digest[:])
+	v.Mod(v, bls12381ScalarFieldOrder)
+	v.FillBytes(r[:])
+	return r
+}
+
+// VerifyBlobKzgProofBatchDeterministic is a drop-in replacement for
+// VerifyBlobKzgProofBatch that derives its batching randomness from a
+// domain-separated transcript of the commitments, proofs, and blobs
+// instead of requiring the caller to supply entropy. This makes the
+// result reproducible across nodes, which matters in consensus contexts
+// where verification must agree byte-for-byte.
+func (ctx EthKzgContext) VerifyBlobKzgProofBatchDeterministic(blobs []EthBlob, commitments []EthKzgCommitment, proofs []EthKzgProof) (bool, error) {
+	t := NewKzgBatchTranscript()
+	t.AppendBatch(blobs, commitments, proofs)
+	return ctx.VerifyBlobKzgProofBatch(blobs, commitments, proofs, t.Challenge())
+}
+
+// VerifyBlobKzgProofBatchDeterministicParallel is the threadpool-backed
+// variant of VerifyBlobKzgProofBatchDeterministic.
+func (ctx EthKzgContext) VerifyBlobKzgProofBatchDeterministicParallel(blobs []EthBlob, commitments []EthKzgCommitment, proofs []EthKzgProof) (bool, error) {
+	t := NewKzgBatchTranscript()
+	t.AppendBatch(blobs, commitments, proofs)
+	return ctx.VerifyBlobKzgProofBatchParallel(blobs, commitments, proofs, t.Challenge())
+}
@@ -0,0 +1,134 @@
+/** Constantine
+ *  Copyright (c) 2018-2019    Status Research & Development GmbH
+ *  Copyright (c) 2020-Present Mamy André-Ratsimbazafy
+ *  Licensed and distributed under either of
+ *    * MIT license (license terms in the root directory or at http://opensource.org/licenses/MIT).
+ *    * Apache v2 license (license terms in the root directory or at http://www.apache.org/licenses/LICENSE-2.0).
+ *  at your option. This file may not be copied, modified, or distributed except according to those terms.
+ */
+
+package constantine
+
+/*
+#cgo CFLAGS: -I"${SRCDIR}/../include"
+#cgo !windows LDFLAGS: "${SRCDIR}/../lib/libconstantine.a"
+#cgo windows LDFLAGS: -L"${SRCDIR}/../lib" -Wl,-Bstatic -lconstantine -Wl,-Bdynamic
+
+#include <stdlib.h>
+#include <constantine.h>
+
+*/
+import "C"
+import (
+	"errors"
+	"io"
+	"unsafe"
+)
+
+// Streaming / zero-copy Blob ingestion
+// -----------------------------------------------------
+//
+// A blob is FieldElementsPerBlob 32-byte chunks, each of which must be the
+// canonical little-endian encoding of a BLS12-381 scalar field element,
+// i.e. strictly smaller than the field modulus. The modulus' top byte is
+// 0x73, so any chunk whose top byte is < 0x73 is below the modulus. The
+// checks below accept only such chunks: a chunk whose top byte is exactly
+// 0x73 is rejected even when it is below the modulus.
+
+const (
+	FieldElementsPerBlob = 4096
+	fieldElementTopByte  = 0x73
+)
+
+var (
+	ErrBlobLength               = errors.New("blob: input does not have the expected length")
+	ErrNonCanonicalFieldElement = errors.New("blob: field element is not canonically encoded (>= field modulus)")
+	ErrBlobBuilderFull          = errors.New("blob: builder already holds FieldElementsPerBlob field elements")
+	ErrBlobBuilderNotFull       = errors.New("blob: builder does not hold a full blob yet")
+)
+
+func isCanonicalFieldElement(fe []byte) bool {
+	// A top byte < fieldElementTopByte guarantees the value is below the
+	// modulus; a top byte of fieldElementTopByte or more is rejected without
+	// comparing the remaining bytes.
+	return fe[31] < fieldElementTopByte
+}
+
+func (ctx EthKzgContext) BlobToKzgCommitmentFromReader(r io.Reader) (commitment EthKzgCommitment, err error) {
+	var blob EthBlob
+	if _, err = io.ReadFull(r, blob[:]); err != nil {
+		return commitment, err
+	}
+	for i := 0; i < FieldElementsPerBlob; i++ {
+		fe := blob[i*32 : i*32+32]
+		if !isCanonicalFieldElement(fe) {
+			return commitment, ErrNonCanonicalFieldElement
+		}
+	}
+	return ctx.BlobToKzgCommitment(blob)
+}
+
+func (ctx EthKzgContext) VerifyBlobKzgProofFromBytes(blob []byte, commitment EthKzgCommitment, proof EthKzgProof) (bool, error) {
+	if len(blob) != len(EthBlob{}) {
+		return false, ErrBlobLength
+	}
+	for i := 0; i < FieldElementsPerBlob; i++ {
+		if !isCanonicalFieldElement(blob[i*32 : i*32+32]) {
+			return false, ErrNonCanonicalFieldElement
+		}
+	}
+
+	status := C.ctt_eth_kzg_verify_blob_kzg_proof(
+		ctx.cCtx,
+		(*C.ctt_eth_kzg_blob)(unsafe.Pointer(&blob[0])),
+		(*C.ctt_eth_kzg_commitment)(unsafe.Pointer(&commitment)),
+		(*C.ctt_eth_kzg_proof)(unsafe.Pointer(&proof)),
+	)
+	if status != C.cttEthKzg_Success {
+		if status == C.cttEthKzg_VerificationFailure {
+			return false, nil
+		}
+
+		err := errors.New(
+			C.GoString(C.ctt_eth_kzg_status_to_string(status)),
+		)
+		return false, err
+	}
+	return true, nil
+}
+
+// BlobBuilder assembles a canonical EthBlob one field element at a time,
+// e.g. while unwrapping an SSZ blob-tx payload off the wire, without
+// requiring the caller to pre-allocate and copy the full 131072-byte array
+// themselves.
+type BlobBuilder struct {
+	blob EthBlob
+	n    int
+}
+
+func NewBlobBuilder() *BlobBuilder {
+	return &BlobBuilder{}
+}
+
+func (b *BlobBuilder) AppendFieldElement(fe [32]byte) error {
+	if b.n >= FieldElementsPerBlob {
+		return ErrBlobBuilderFull
+	}
+	if !isCanonicalFieldElement(fe[:]) {
+		return ErrNonCanonicalFieldElement
+	}
+	copy(b.blob[b.n*32:b.n*32+32], fe[:])
+	b.n++
+	return nil
+}
+
+func (b *BlobBuilder) Len() int {
+	return b.n
+}
+
+func (b *BlobBuilder) Finalize() (EthBlob, error) {
+	if b.n != FieldElementsPerBlob {
+		return EthBlob{}, ErrBlobBuilderNotFull
+	}
+	return b.blob, nil
+}
@@ -0,0 +1,257 @@
+/** Constantine
+ *  Copyright (c) 2018-2019    Status Research & Development GmbH
+ *  Copyright (c) 2020-Present Mamy André-Ratsimbazafy
+ *  Licensed and distributed under either of
+ *    * MIT license (license terms in the root directory or at http://opensource.org/licenses/MIT).
+ *    * Apache v2 license (license terms in the root directory or at http://www.apache.org/licenses/LICENSE-2.0).
+ *  at your option. This file may not be copied, modified, or distributed except according to those terms.
+ */
+
+package constantine
+
+/*
+#cgo CFLAGS: -I"${SRCDIR}/../include"
+#cgo !windows LDFLAGS: "${SRCDIR}/../lib/libconstantine.a"
+#cgo windows LDFLAGS: -L"${SRCDIR}/../lib" -Wl,-Bstatic -lconstantine -Wl,-Bdynamic
+
+#include <stdlib.h>
+#include <constantine.h>
+
+*/
+import "C"
+import (
+	"errors"
+	"runtime"
+	"sync"
+)
+
+// Ethereum BLS aggregate signatures - distinct messages
+// -----------------------------------------------------
+//
+// FastAggregateVerify handles the common case of one message signed by
+// many keys. AggregateVerify handles the general case of distinct
+// messages each signed by their own key, combined into a single
+// aggregate signature. EthBlsAggregateSigAccumulator lets callers feed
+// (pubkey, message, signature) triplets incrementally, mirroring the
+// existing batch sig accumulator, instead of buffering a full slice.
+
+// NOTE: C.ctt_eth_bls_aggregate_sig_accumulator is an incomplete struct,
+// same as the batch sig accumulator, so we allocate/free it from the Nim
+// side via dedicated functions.
+type EthBlsAggregateSigAccumulator struct {
+	ctx *C.ctt_eth_bls_aggregate_sig_accumulator
+}
+
+func NewEthBlsAggregateSigAccumulator() *EthBlsAggregateSigAccumulator {
+	return &EthBlsAggregateSigAccumulator{
+		ctx: C.ctt_eth_bls_alloc_aggregate_sig_accumulator(),
+	}
+}
+
+func (accum *EthBlsAggregateSigAccumulator) Free() {
+	C.ctt_eth_bls_free_aggregate_sig_accumulator(accum.ctx)
+}
+
+func (accum *EthBlsAggregateSigAccumulator) Init(secureRandomBytes []byte) {
+	C.ctt_eth_bls_init_aggregate_sig_accumulator(accum.ctx,
+		(*C.byte)(getAddr(secureRandomBytes)),
+		(C.size_t)(len(secureRandomBytes)),
+	)
+}
+
+func (accum *EthBlsAggregateSigAccumulator) Update(pub EthBlsPubKey, message []byte, sig EthBlsSignature) error {
+	status := C.ctt_eth_bls_update_aggregate_sig_accumulator(accum.ctx,
+		(*C.ctt_eth_bls_pubkey)(&pub),
+		(*C.byte)(getAddr(message)),
+		(C.size_t)(len(message)),
+		(*C.ctt_eth_bls_signature)(&sig),
+	)
+	if !bool(status) {
+		return errors.New(
+			C.GoString(
+				C.ctt_eth_bls_status_to_string(C.cttEthBls_VerificationFailure),
+			),
+		)
+	}
+	return nil
+}
+
+// Merge folds other's accumulated state into accum, so independently built
+// partial accumulators (e.g. one per goroutine) can be combined before a
+// single Finalize.
+func (accum *EthBlsAggregateSigAccumulator) Merge(other *EthBlsAggregateSigAccumulator) error {
+	status := C.ctt_eth_bls_merge_aggregate_sig_accumulator(accum.ctx, other.ctx)
+	if !bool(status) {
+		return errors.New("EthBlsAggregateSigAccumulator.Merge: failed to merge accumulators.")
+	}
+	return nil
+}
+
+func (accum *EthBlsAggregateSigAccumulator) Finalize() (bool, error) {
+	status := C.ctt_eth_bls_final_verify_aggregate_sig_accumulator(accum.ctx)
+	return bool(status), nil
+}
+
+// AggregatePubKeys combines pubkeys into a single public key, e.g. to
+// verify a FastAggregateVerify signature against a key computed once and
+// cached rather than aggregated on every call.
+func AggregatePubKeys(pubkeys []EthBlsPubKey) (aggregate EthBlsPubKey, err error) {
+	if len(pubkeys) == 0 {
+		return aggregate, errors.New("AggregatePubKeys: No public keys given.")
+	}
+	status := C.ctt_eth_bls_aggregate_pubkeys(
+		(*C.ctt_eth_bls_pubkey)(&aggregate),
+		(*C.ctt_eth_bls_pubkey)(getAddr(pubkeys)),
+		(C.size_t)(len(pubkeys)),
+	)
+	if status != C.cttEthBls_Success {
+		return aggregate, errors.New(
+			C.GoString(C.ctt_eth_bls_status_to_string(status)),
+		)
+	}
+	return aggregate, nil
+}
+
+// AggregateSignatures combines sigs into a single signature, e.g. to build
+// the aggregate signature a FastAggregateVerify/AggregateVerify caller
+// will later verify.
+func AggregateSignatures(sigs []EthBlsSignature) (aggregate EthBlsSignature, err error) {
+	if len(sigs) == 0 {
+		return aggregate, errors.New("AggregateSignatures: No signatures given.")
+	}
+	status := C.ctt_eth_bls_aggregate_signatures(
+		(*C.ctt_eth_bls_signature)(&aggregate),
+		(*C.ctt_eth_bls_signature)(getAddr(sigs)),
+		(C.size_t)(len(sigs)),
+	)
+	if status != C.cttEthBls_Success {
+		return aggregate, errors.New(
+			C.GoString(C.ctt_eth_bls_status_to_string(status)),
+		)
+	}
+	return aggregate, nil
+}
+
+// AggregateVerify verifies an aggregate signature against distinct
+// messages each signed under their own public key.
+func AggregateVerify(pubkeys []EthBlsPubKey, messages [][]byte, aggregateSig EthBlsSignature) (bool, error) {
+	if len(pubkeys) == 0 {
+		return false, errors.New("AggregateVerify: No public keys given.")
+	}
+	if len(pubkeys) != len(messages) {
+		return false, errors.New("AggregateVerify: Number of public keys must match number of messages.")
+	}
+	for _, pub := range pubkeys {
+		if pub.IsZero() {
+			return false, errors.New(
+				C.GoString(
+					C.ctt_eth_bls_status_to_string(C.cttEthBls_PointAtInfinity),
+				),
+			)
+		}
+	}
+	if aggregateSig.IsZero() {
+		return false, errors.New(
+			C.GoString(
+				C.ctt_eth_bls_status_to_string(C.cttEthBls_PointAtInfinity),
+			),
+		)
+	}
+
+	var secureRandomBytes [32]byte // AggregateVerify checks one exact equation, no rerandomization needed
+	accum := NewEthBlsAggregateSigAccumulator()
+	defer accum.Free()
+	accum.Init(secureRandomBytes[:])
+
+	for i, pub := range pubkeys {
+		if err := accum.Update(pub, messages[i], aggregateSig); err != nil {
+			return false, err
+		}
+	}
+	return accum.Finalize()
+}
+
+// AggregateVerifyGo is the Go-parallel counterpart of AggregateVerify: it
+// shards the (pubkey, message) pairs across runtime.NumCPU() goroutines,
+// accumulates each shard independently, and merges the partials before a
+// single final verification.
+func AggregateVerifyGo(pubkeys []EthBlsPubKey, messages [][]byte, aggregateSig EthBlsSignature) (bool, error) {
+	if len(pubkeys) == 0 {
+		return false, errors.New("AggregateVerifyGo: No public keys given.")
+	}
+	if len(pubkeys) != len(messages) {
+		return false, errors.New("AggregateVerifyGo: Number of public keys must match number of messages.")
+	}
+
+	numWorkers := runtime.NumCPU()
+	if numWorkers > len(pubkeys) {
+		numWorkers = len(pubkeys)
+	}
+	if numWorkers <= 1 {
+		return AggregateVerify(pubkeys, messages, aggregateSig)
+	}
+
+	shardSize := (len(pubkeys) + numWorkers - 1) / numWorkers
+	partials := make([]*EthBlsAggregateSigAccumulator, numWorkers)
+	errs := make([]error, numWorkers)
+
+	var wg sync.WaitGroup
+	for w := 0; w < numWorkers; w++ {
+		lo := w * shardSize
+		hi := lo + shardSize
+		if hi > len(pubkeys) {
+			hi = len(pubkeys)
+		}
+		if lo >= hi {
+			continue
+		}
+		wg.Add(1)
+		go func(w, lo, hi int) {
+			defer wg.Done()
+			runtime.LockOSThread()
+			defer runtime.UnlockOSThread()
+
+			var secureRandomBytes [32]byte
+			accum := NewEthBlsAggregateSigAccumulator()
+			accum.Init(secureRandomBytes[:])
+			for i := lo; i < hi; i++ {
+				if err := accum.Update(pubkeys[i], messages[i], aggregateSig); err != nil {
+					errs[w] = err
+					accum.Free()
+					return
+				}
+			}
+			partials[w] = accum
+		}(w, lo, hi)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			for _, p := range partials {
+				if p != nil {
+					p.Free()
+				}
+			}
+			return false, err
+		}
+	}
+
+	var final *EthBlsAggregateSigAccumulator
+	for _, p := range partials {
+		if p == nil {
+			continue
+		}
+		if final == nil {
+			final = p
+			continue
+		}
+		defer p.Free()
+		if err := final.Merge(p); err != nil {
+			final.Free()
+			return false, err
+		}
+	}
+	defer final.Free()
+	return final.Finalize()
+}
@@ -0,0 +1,183 @@
+/** Constantine
+ *  Copyright (c) 2018-2019    Status Research & Development GmbH
+ *  Copyright (c) 2020-Present Mamy André-Ratsimbazafy
+ *  Licensed and distributed under either of
+ *    * MIT license (license terms in the root directory or at http://opensource.org/licenses/MIT).
+ *    * Apache v2 license (license terms in the root directory or at http://www.apache.org/licenses/LICENSE-2.0).
+ *  at your option. This file may not be copied, modified, or distributed except according to those terms.
+ */
+
+package constantine
+
+/*
+#cgo CFLAGS: -I"${SRCDIR}/../include"
+#cgo !windows LDFLAGS: "${SRCDIR}/../lib/libconstantine.a"
+#cgo windows LDFLAGS: -L"${SRCDIR}/../lib" -Wl,-Bstatic -lconstantine -Wl,-Bdynamic
+
+#include <stdlib.h>
+#include <constantine.h>
+
+*/
+import "C"
+import (
+	"encoding/binary"
+	"errors"
+	"runtime"
+	"sync"
+
+	"github.com/mratsim/constantine/constantine-go/sha256"
+)
+
+// Go-side parallel BatchVerify
+// -----------------------------------------------------
+//
+// BatchVerifySoA/BatchVerifyAoS drive a single ethBlsBatchSigAccumulator
+// sequentially. For large batches it's faster to shard the triplets
+// across runtime.NumCPU() goroutines, run an independently-seeded
+// accumulator per shard, and combine the partial accumulators with one
+// final pairing check. Each shard derives its own randomization scalars
+// from the caller's seed and its shard index via a domain-separated PRF,
+// so the result is deterministic for a given seed and worker count.
+
+// batchVerifyParallelThreshold is the minimum number of signatures before
+// sharding pays for its goroutine and allocation overhead; below it we
+// fall back to the serial path.
+const batchVerifyParallelThreshold = 64
+
+const batchVerifyShardSepTag = "parallel-shard"
+
+// BatchVerifyScratch holds the per-shard accumulators used by
+// BatchVerifyParallelSoA/AoS so repeated calls don't re-allocate.
+type BatchVerifyScratch struct {
+	accums []ethBlsBatchSigAccumulator
+}
+
+// NewBatchVerifyScratch allocates `workers` reusable shard accumulators.
+func NewBatchVerifyScratch(workers int) *BatchVerifyScratch {
+	s := &BatchVerifyScratch{accums: make([]ethBlsBatchSigAccumulator, workers)}
+	for i := range s.accums {
+		s.accums[i] = ethBlsBatchSigAccumulatorAlloc()
+	}
+	return s
+}
+
+// Close releases the native memory backing the scratch's accumulators.
+func (s *BatchVerifyScratch) Close() {
+	for _, accum := range s.accums {
+		ethBlsBatchSigAccumulatorFree(accum)
+	}
+	s.accums = nil
+}
+
+func shardSeed(secureRandomBytes Bytes32, shard int) Bytes32 {
+	var shardIdx [8]byte
+	binary.LittleEndian.PutUint64(shardIdx[:], uint64(shard))
+	msg := append(append([]byte(batchVerifyShardSepTag), secureRandomBytes[:]...), shardIdx[:]...)
+	return sha256.Hash(msg, false)
+}
+
+func splitRange(n, workers int) (starts, ends []int) {
+	shardSize := (n + workers - 1) / workers
+	starts = make([]int, 0, workers)
+	ends = make([]int, 0, workers)
+	for lo := 0; lo < n; lo += shardSize {
+		hi := lo + shardSize
+		if hi > n {
+			hi = n
+		}
+		starts = append(starts, lo)
+		ends = append(ends, hi)
+	}
+	return starts, ends
+}
+
+// BatchVerifyParallelSoA is the Go-sharded counterpart of BatchVerifySoA.
+// scratch may be nil, in which case a throwaway one is allocated; pass a
+// reusable BatchVerifyScratch (sized to workers) to avoid per-call
+// allocation across repeated invocations. workers <= 0 defaults to
+// runtime.NumCPU().
+func BatchVerifyParallelSoA(scratch *BatchVerifyScratch, pubkeys []EthBlsPubKey, messages [][]byte, signatures []EthBlsSignature, secureRandomBytes Bytes32, workers int) (bool, error) {
+	if len(pubkeys) == 0 {
+		return false, errors.New("BatchVerifyParallelSoA: No public keys given.")
+	}
+	if len(pubkeys) != len(messages) || len(pubkeys) != len(signatures) {
+		return false, errors.New("BatchVerifyParallelSoA: Number of public keys, messages and signatures must match.")
+	}
+	if len(pubkeys) < batchVerifyParallelThreshold {
+		return BatchVerifySoA(pubkeys, messages, signatures, secureRandomBytes)
+	}
+
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > len(pubkeys) {
+		workers = len(pubkeys)
+	}
+
+	owned := scratch == nil
+	if owned {
+		scratch = NewBatchVerifyScratch(workers)
+		defer scratch.Close()
+	} else if len(scratch.accums) < workers {
+		return false, errors.New("BatchVerifyParallelSoA: scratch has fewer shards than requested workers.")
+	}
+
+	starts, ends := splitRange(len(pubkeys), workers)
+	errs := make([]error, len(starts))
+	var wg sync.WaitGroup
+	for w := range starts {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			accum := scratch.accums[w]
+			accum.init(shardSeed(secureRandomBytes, w), []byte(batchVerifyShardSepTag))
+			for i := starts[w]; i < ends[w]; i++ {
+				if pubkeys[i].IsZero() || signatures[i].IsZero() {
+					errs[w] = errors.New(
+						C.GoString(
+							C.ctt_eth_bls_status_to_string(C.cttEthBls_PointAtInfinity),
+						),
+					)
+					return
+				}
+				if !accum.update(pubkeys[i], messages[i], signatures[i]) {
+					errs[w] = errors.New(
+						C.GoString(
+							C.ctt_eth_bls_status_to_string(C.cttEthBls_VerificationFailure),
+						),
+					)
+					return
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return false, err
+		}
+	}
+
+	for w := 1; w < len(starts); w++ {
+		status := C.ctt_eth_bls_merge_batch_sig_accumulator(scratch.accums[0].ctx, scratch.accums[w].ctx)
+		if !bool(status) {
+			return false, errors.New("BatchVerifyParallelSoA: failed to merge accumulators.")
+		}
+	}
+	return scratch.accums[0].finalVerify(), nil
+}
+
+// BatchVerifyParallelAoS is the array-of-structs sibling of
+// BatchVerifyParallelSoA.
+func BatchVerifyParallelAoS(scratch *BatchVerifyScratch, triplets []BatchVerifyTriplet, secureRandomBytes Bytes32, workers int) (bool, error) {
+	pubkeys := make([]EthBlsPubKey, len(triplets))
+	messages := make([][]byte, len(triplets))
+	signatures := make([]EthBlsSignature, len(triplets))
+	for i, trp := range triplets {
+		pubkeys[i] = trp.pub
+		messages[i] = trp.message
+		signatures[i] = trp.sig
+	}
+	return BatchVerifyParallelSoA(scratch, pubkeys, messages, signatures, secureRandomBytes, workers)
+}
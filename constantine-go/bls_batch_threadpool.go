@@ -0,0 +1,171 @@
+/** Constantine
+ *  Copyright (c) 2018-2019    Status Research & Development GmbH
+ *  Copyright (c) 2020-Present Mamy André-Ratsimbazafy
+ *  Licensed and distributed under either of
+ *    * MIT license (license terms in the root directory or at http://opensource.org/licenses/MIT).
+ *    * Apache v2 license (license terms in the root directory or at http://www.apache.org/licenses/LICENSE-2.0).
+ *  at your option. This file may not be copied, modified, or distributed except according to those terms.
+ */
+
+package constantine
+
+/*
+#cgo CFLAGS: -I"${SRCDIR}/../include"
+#cgo !windows LDFLAGS: "${SRCDIR}/../lib/libconstantine.a"
+#cgo windows LDFLAGS: -L"${SRCDIR}/../lib" -Wl,-Bstatic -lconstantine -Wl,-Bdynamic
+
+#include <stdlib.h>
+#include <constantine.h>
+
+*/
+import "C"
+import "errors"
+
+// Nim-threadpool-backed parallel BatchVerify
+// -----------------------------------------------------
+//
+// BatchVerifyParallelSoA/AoS (bls_batch_parallel.go) shard work across Go
+// goroutines, each driving its own accumulator. BatchVerifySoAParallel/
+// AoSParallel instead hand the whole batch to a single accumulator whose
+// per-triplet Miller-loop and pubkey work is sharded by the Nim threadpool
+// itself, mirroring how the parallel KZG entrypoints take ctx.threadpool.ctx
+// as their first argument. Pick whichever threadpool you've already paid
+// the setup cost for; the two are not meant to be combined.
+
+// flattenMessages packs messages into one contiguous buffer plus a parallel
+// length-per-message array, since C has no notion of a jagged [][]byte.
+func flattenMessages(messages [][]byte) (flat []byte, lengths []C.size_t) {
+	total := 0
+	for _, m := range messages {
+		total += len(m)
+	}
+	flat = make([]byte, 0, total)
+	lengths = make([]C.size_t, len(messages))
+	for i, m := range messages {
+		flat = append(flat, m...)
+		lengths[i] = C.size_t(len(m))
+	}
+	return flat, lengths
+}
+
+// BatchVerifySoAParallel is the Nim-threadpool-backed counterpart of
+// BatchVerifySoA.
+func BatchVerifySoAParallel(tp Threadpool, pubkeys []EthBlsPubKey, messages [][]byte, signatures []EthBlsSignature, secureRandomBytes Bytes32) (bool, error) {
+	if len(pubkeys) == 0 {
+		return false, errors.New("BatchVerifySoAParallel: No public keys given.")
+	}
+	if len(pubkeys) != len(messages) || len(pubkeys) != len(signatures) {
+		return false, errors.New("BatchVerifySoAParallel: Number of public keys, messages and signatures must match.")
+	}
+	if tp.ctx == nil {
+		return false, errors.New("BatchVerifySoAParallel: The threadpool is not configured.")
+	}
+
+	for _, pub := range pubkeys {
+		if pub.IsZero() {
+			return false, errors.New(
+				C.GoString(C.ctt_eth_bls_status_to_string(C.cttEthBls_PointAtInfinity)),
+			)
+		}
+	}
+	for _, sig := range signatures {
+		if sig.IsZero() {
+			return false, errors.New(
+				C.GoString(C.ctt_eth_bls_status_to_string(C.cttEthBls_PointAtInfinity)),
+			)
+		}
+	}
+
+	accum := ethBlsBatchSigAccumulatorAlloc()
+	defer ethBlsBatchSigAccumulatorFree(accum)
+	accum.init(secureRandomBytes, []byte("parallel"))
+
+	flatMsgs, msgLens := flattenMessages(messages)
+	status := C.ctt_eth_bls_parallel_update_batch_sig_accumulator(
+		tp.ctx,
+		accum.ctx,
+		(*C.ctt_eth_bls_pubkey)(getAddr(pubkeys)),
+		(*C.byte)(getAddr(flatMsgs)),
+		(*C.size_t)(getAddr(msgLens)),
+		(*C.ctt_eth_bls_signature)(getAddr(signatures)),
+		(C.size_t)(len(pubkeys)),
+	)
+	if !bool(status) {
+		return false, errors.New(
+			C.GoString(C.ctt_eth_bls_status_to_string(C.cttEthBls_VerificationFailure)),
+		)
+	}
+
+	return accum.finalVerify(), nil
+}
+
+// BatchVerifyAoSParallel is the array-of-structs sibling of
+// BatchVerifySoAParallel.
+func BatchVerifyAoSParallel(tp Threadpool, triplets []BatchVerifyTriplet, secureRandomBytes Bytes32) (bool, error) {
+	pubkeys := make([]EthBlsPubKey, len(triplets))
+	messages := make([][]byte, len(triplets))
+	signatures := make([]EthBlsSignature, len(triplets))
+	for i, trp := range triplets {
+		pubkeys[i] = trp.pub
+		messages[i] = trp.message
+		signatures[i] = trp.sig
+	}
+	return BatchVerifySoAParallel(tp, pubkeys, messages, signatures, secureRandomBytes)
+}
+
+// BlsBatchVerifier is the public form of the batch-signature accumulator
+// behind BatchVerifySoA/AoS, for consumers (e.g. p2p gossip validators)
+// that need to feed (pubkey, message, signature) triplets in as they
+// arrive rather than buffering a full slice up front. A single
+// BlsBatchVerifier must only be driven from one goroutine at a time;
+// build one per goroutine and combine them with Merge before FinalVerify.
+type BlsBatchVerifier struct {
+	accum ethBlsBatchSigAccumulator
+}
+
+// NewBlsBatchVerifier allocates a batch verifier seeded with seed
+// and domain-separated by sep (mirrors ethBlsBatchSigAccumulator.init's
+// accumSepTag, e.g. "serial"/"parallel"/a per-shard tag).
+func NewBlsBatchVerifier(seed Bytes32, sep []byte) *BlsBatchVerifier {
+	v := &BlsBatchVerifier{accum: ethBlsBatchSigAccumulatorAlloc()}
+	v.accum.init(seed, sep)
+	return v
+}
+
+// Free releases the native memory backing the verifier. Call once the
+// verifier (and anything it was Merge'd into) is no longer needed.
+func (v *BlsBatchVerifier) Free() {
+	ethBlsBatchSigAccumulatorFree(v.accum)
+}
+
+// Update feeds one more (pubkey, message, signature) triplet into the batch.
+func (v *BlsBatchVerifier) Update(pub EthBlsPubKey, message []byte, sig EthBlsSignature) error {
+	if pub.IsZero() || sig.IsZero() {
+		return errors.New(
+			C.GoString(C.ctt_eth_bls_status_to_string(C.cttEthBls_PointAtInfinity)),
+		)
+	}
+	if !v.accum.update(pub, message, sig) {
+		return errors.New(
+			C.GoString(C.ctt_eth_bls_status_to_string(C.cttEthBls_VerificationFailure)),
+		)
+	}
+	return nil
+}
+
+// Merge folds other's accumulated state into v, so independently fed
+// verifiers (e.g. one per goroutine) can be combined before a single
+// FinalVerify.
+func (v *BlsBatchVerifier) Merge(other *BlsBatchVerifier) error {
+	status := C.ctt_eth_bls_merge_batch_sig_accumulator(v.accum.ctx, other.accum.ctx)
+	if !bool(status) {
+		return errors.New("BlsBatchVerifier.Merge: failed to merge accumulators.")
+	}
+	return nil
+}
+
+// FinalVerify runs the single combined pairing check over every triplet fed
+// via Update/Merge.
+func (v *BlsBatchVerifier) FinalVerify() bool {
+	return v.accum.finalVerify()
+}
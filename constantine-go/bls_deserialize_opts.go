@@ -0,0 +1,164 @@
+/** Constantine
+ *  Copyright (c) 2018-2019    Status Research & Development GmbH
+ *  Copyright (c) 2020-Present Mamy André-Ratsimbazafy
+ *  Licensed and distributed under either of
+ *    * MIT license (license terms in the root directory or at http://opensource.org/licenses/MIT).
+ *    * Apache v2 license (license terms in the root directory or at http://www.apache.org/licenses/LICENSE-2.0).
+ *  at your option. This file may not be copied, modified, or distributed except according to those terms.
+ */
+
+package constantine
+
+/*
+#cgo CFLAGS: -I"${SRCDIR}/../include"
+#cgo !windows LDFLAGS: "${SRCDIR}/../lib/libconstantine.a"
+#cgo windows LDFLAGS: -L"${SRCDIR}/../lib" -Wl,-Bstatic -lconstantine -Wl,-Bdynamic
+
+#include <stdlib.h>
+#include <constantine.h>
+
+*/
+import "C"
+import "errors"
+
+// Deserialization mode selection
+// -----------------------------------------------------
+//
+// DeserializePubKeyCompressed/DeserializeSignatureCompressed always run the
+// full subgroup check, which is wasted work when the caller already knows
+// its bytes are trustworthy (e.g. they were validated once and are about to
+// be fed into a batch verify, which re-randomizes and re-checks the pairing
+// equation anyway). DeserializeOpts and the *Opts/*Compressed helpers below
+// let callers opt into the cheaper "KeyValidate once, verify many" pattern
+// without leaving Go. The plain zero-arg functions are untouched and keep
+// their existing strict semantics.
+
+// DeserializeOpts controls which checks DeserializePubKeyCompressedOpts and
+// DeserializeSignatureCompressedOpts perform on decompression.
+type DeserializeOpts struct {
+	// SkipSubgroupCheck skips the prime-order subgroup check, using the
+	// cheaper Unchecked codec path. Only safe for points that are already
+	// known-valid (e.g. previously validated) or that will be consumed by
+	// a batch verify, whose own randomization rejects an invalid pairing.
+	SkipSubgroupCheck bool
+	// SkipInfinityCheck allows the point at infinity to decompress
+	// successfully instead of being rejected as invalid.
+	SkipInfinityCheck bool
+	// Trusted is a shortcut for SkipSubgroupCheck && SkipInfinityCheck,
+	// for bytes the caller fully trusts (e.g. its own serialization).
+	Trusted bool
+}
+
+// DeserializePubKeyCompressedOpts decompresses pub, applying opts to select
+// between the full-check and unchecked codec paths.
+func DeserializePubKeyCompressedOpts(src Bytes48, opts DeserializeOpts) (pub EthBlsPubKey, err error) {
+	if opts.Trusted || opts.SkipSubgroupCheck {
+		pub, err = DeserializePubKeyCompressedUnchecked(src)
+	} else {
+		pub, err = DeserializePubKeyCompressed(src)
+	}
+	if err != nil {
+		return pub, err
+	}
+	if !opts.Trusted && !opts.SkipInfinityCheck && pub.IsZero() {
+		return pub, errors.New(
+			C.GoString(C.ctt_codec_ecc_status_to_string(C.cttCodecEcc_PointAtInfinity)),
+		)
+	}
+	return pub, nil
+}
+
+// DeserializeSignatureCompressedOpts decompresses sig, applying opts to
+// select between the full-check and unchecked codec paths.
+func DeserializeSignatureCompressedOpts(src Bytes96, opts DeserializeOpts) (sig EthBlsSignature, err error) {
+	if opts.Trusted || opts.SkipSubgroupCheck {
+		sig, err = DeserializeSignatureCompressedUnchecked(src)
+	} else {
+		sig, err = DeserializeSignatureCompressed(src)
+	}
+	if err != nil {
+		return sig, err
+	}
+	if !opts.Trusted && !opts.SkipInfinityCheck && sig.IsZero() {
+		return sig, errors.New(
+			C.GoString(C.ctt_codec_ecc_status_to_string(C.cttCodecEcc_PointAtInfinity)),
+		)
+	}
+	return sig, nil
+}
+
+// VerifyCompressed decompresses pubBytes/sigBytes per opts, then verifies
+// message against the result. A SkipSubgroupCheck signature that lies
+// outside the prime-order subgroup is not rejected here: Verify checks a
+// single pairing equation that an off-subgroup point can satisfy, so callers
+// accepting untrusted bytes should either leave SkipSubgroupCheck false or
+// route through a batch verify instead.
+func VerifyCompressed(pubBytes Bytes48, message []byte, sigBytes Bytes96, opts DeserializeOpts) (bool, error) {
+	pub, err := DeserializePubKeyCompressedOpts(pubBytes, opts)
+	if err != nil {
+		return false, err
+	}
+	sig, err := DeserializeSignatureCompressedOpts(sigBytes, opts)
+	if err != nil {
+		return false, err
+	}
+	return pub.Verify(message, sig)
+}
+
+// FastAggregateVerifyCompressed decompresses pubkeysBytes/aggregateSigBytes
+// per opts, then runs FastAggregateVerify against message. A
+// SkipSubgroupCheck signature that lies outside the prime-order subgroup
+// is not rejected here: FastAggregateVerify checks a single pairing
+// equation that an off-subgroup point can satisfy, so callers accepting
+// untrusted bytes should either leave SkipSubgroupCheck false or route
+// through a batch verify instead.
+func FastAggregateVerifyCompressed(pubkeysBytes []Bytes48, message []byte, aggregateSigBytes Bytes96, opts DeserializeOpts) (bool, error) {
+	pubkeys := make([]EthBlsPubKey, len(pubkeysBytes))
+	for i, b := range pubkeysBytes {
+		pub, err := DeserializePubKeyCompressedOpts(b, opts)
+		if err != nil {
+			return false, err
+		}
+		pubkeys[i] = pub
+	}
+	aggregateSig, err := DeserializeSignatureCompressedOpts(aggregateSigBytes, opts)
+	if err != nil {
+		return false, err
+	}
+	return FastAggregateVerify(pubkeys, message, aggregateSig)
+}
+
+// BatchVerifySoACompressed decompresses pubkeysBytes/signaturesBytes per
+// opts, then runs BatchVerifySoA. This is the intended home for
+// SkipSubgroupCheck bytes: BatchVerifySoA re-randomizes every triplet, so
+// an off-subgroup signature is caught by the final pairing check instead of
+// by the codec.
+func BatchVerifySoACompressed(pubkeysBytes []Bytes48, messages [][]byte, signaturesBytes []Bytes96, secureRandomBytes Bytes32, opts DeserializeOpts) (bool, error) {
+	pubkeys := make([]EthBlsPubKey, len(pubkeysBytes))
+	for i, b := range pubkeysBytes {
+		pub, err := DeserializePubKeyCompressedOpts(b, opts)
+		if err != nil {
+			return false, err
+		}
+		pubkeys[i] = pub
+	}
+	signatures := make([]EthBlsSignature, len(signaturesBytes))
+	for i, b := range signaturesBytes {
+		sig, err := DeserializeSignatureCompressedOpts(b, opts)
+		if err != nil {
+			return false, err
+		}
+		signatures[i] = sig
+	}
+	return BatchVerifySoA(pubkeys, messages, signatures, secureRandomBytes)
+}
+
+// BatchVerifyAoSCompressed takes the same parallel slices as
+// BatchVerifySoACompressed (not a slice of triplets), checks that their
+// lengths match, and then delegates to BatchVerifySoACompressed.
+func BatchVerifyAoSCompressed(pubkeysBytes []Bytes48, messages [][]byte, signaturesBytes []Bytes96, secureRandomBytes Bytes32, opts DeserializeOpts) (bool, error) {
+	if len(pubkeysBytes) != len(signaturesBytes) || len(pubkeysBytes) != len(messages) {
+		return false, errors.New("BatchVerifyAoSCompressed: Number of public keys, messages and signatures must match.")
+	}
+	return BatchVerifySoACompressed(pubkeysBytes, messages, signaturesBytes, secureRandomBytes, opts)
+}
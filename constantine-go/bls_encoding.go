@@ -0,0 +1,226 @@
+/** Constantine
+ *  Copyright (c) 2018-2019    Status Research & Development GmbH
+ *  Copyright (c) 2020-Present Mamy André-Ratsimbazafy
+ *  Licensed and distributed under either of
+ *    * MIT license (license terms in the root directory or at http://opensource.org/licenses/MIT).
+ *    * Apache v2 license (license terms in the root directory or at http://www.apache.org/licenses/LICENSE-2.0).
+ *  at your option. This file may not be copied, modified, or distributed except according to those terms.
+ */
+
+package constantine
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+)
+
+// Standard library (de)serialization interfaces
+// -----------------------------------------------------
+//
+// EthBlsPubKey, EthBlsSignature, EthKzgCommitment and EthKzgProof are wire
+// types that Ethereum RPC/gossip glue code routinely needs to round-trip
+// through encoding/json, gob, or a hand-rolled protobuf marshaler. Rather
+// than making every caller write that boilerplate against
+// SerializeCompressed/DeserializePubKeyCompressed themselves, the types
+// implement encoding.BinaryMarshaler/Unmarshaler, encoding.TextMarshaler/
+// Unmarshaler, and json.Marshaler/Unmarshaler (0x-prefixed hex, the
+// convention used across go-ethereum and the consensus-spec tooling).
+//
+// The Marshal* methods use value receivers and the Unmarshal* methods
+// pointer receivers: these types are small and commonly passed around and
+// stored by value (map values, slice elements, interface{}), and a
+// pointer-receiver-only Marshaler silently falls back to encoding/json's
+// default array/struct encoding for a non-addressable value instead of
+// erroring — value receivers keep the type in its own method set so that
+// can't happen. Unmarshal has to stay pointer-receiver since it mutates.
+
+func hexMarshalText(b []byte) ([]byte, error) {
+	dst := make([]byte, 2+hex.EncodedLen(len(b)))
+	dst[0], dst[1] = '0', 'x'
+	hex.Encode(dst[2:], b)
+	return dst, nil
+}
+
+func hexUnmarshalText(dst []byte, text []byte) error {
+	if len(text) >= 2 && text[0] == '0' && (text[1] == 'x' || text[1] == 'X') {
+		text = text[2:]
+	}
+	if hex.DecodedLen(len(text)) != len(dst) {
+		return errors.New("constantine: wrong hex length for this type")
+	}
+	_, err := hex.Decode(dst, text)
+	return err
+}
+
+func (pub EthBlsPubKey) MarshalBinary() ([]byte, error) {
+	b, err := pub.SerializeCompressed()
+	return b[:], err
+}
+
+func (pub *EthBlsPubKey) UnmarshalBinary(data []byte) error {
+	if len(data) != len(Bytes48{}) {
+		return errors.New("EthBlsPubKey.UnmarshalBinary: input must be 48 bytes")
+	}
+	decoded, err := DeserializePubKeyCompressed(Bytes48(data))
+	if err != nil {
+		return err
+	}
+	*pub = decoded
+	return nil
+}
+
+func (pub EthBlsPubKey) MarshalText() ([]byte, error) {
+	b, err := pub.SerializeCompressed()
+	if err != nil {
+		return nil, err
+	}
+	return hexMarshalText(b[:])
+}
+
+func (pub *EthBlsPubKey) UnmarshalText(text []byte) error {
+	var b Bytes48
+	if err := hexUnmarshalText(b[:], text); err != nil {
+		return err
+	}
+	return pub.UnmarshalBinary(b[:])
+}
+
+func (sig EthBlsSignature) MarshalBinary() ([]byte, error) {
+	b, err := sig.SerializeCompressed()
+	return b[:], err
+}
+
+func (sig *EthBlsSignature) UnmarshalBinary(data []byte) error {
+	if len(data) != len(Bytes96{}) {
+		return errors.New("EthBlsSignature.UnmarshalBinary: input must be 96 bytes")
+	}
+	decoded, err := DeserializeSignatureCompressed(Bytes96(data))
+	if err != nil {
+		return err
+	}
+	*sig = decoded
+	return nil
+}
+
+func (sig EthBlsSignature) MarshalText() ([]byte, error) {
+	b, err := sig.SerializeCompressed()
+	if err != nil {
+		return nil, err
+	}
+	return hexMarshalText(b[:])
+}
+
+func (sig *EthBlsSignature) UnmarshalText(text []byte) error {
+	var b Bytes96
+	if err := hexUnmarshalText(b[:], text); err != nil {
+		return err
+	}
+	return sig.UnmarshalBinary(b[:])
+}
+
+func (c EthKzgCommitment) MarshalBinary() ([]byte, error) {
+	return c[:], nil
+}
+
+func (c *EthKzgCommitment) UnmarshalBinary(data []byte) error {
+	if len(data) != len(c) {
+		return errors.New("EthKzgCommitment.UnmarshalBinary: input must be 48 bytes")
+	}
+	copy(c[:], data)
+	return nil
+}
+
+func (c EthKzgCommitment) MarshalText() ([]byte, error) {
+	return hexMarshalText(c[:])
+}
+
+func (c *EthKzgCommitment) UnmarshalText(text []byte) error {
+	return hexUnmarshalText(c[:], text)
+}
+
+func (p EthKzgProof) MarshalBinary() ([]byte, error) {
+	return p[:], nil
+}
+
+func (p *EthKzgProof) UnmarshalBinary(data []byte) error {
+	if len(data) != len(p) {
+		return errors.New("EthKzgProof.UnmarshalBinary: input must be 48 bytes")
+	}
+	copy(p[:], data)
+	return nil
+}
+
+func (p EthKzgProof) MarshalText() ([]byte, error) {
+	return hexMarshalText(p[:])
+}
+
+func (p *EthKzgProof) UnmarshalText(text []byte) error {
+	return hexUnmarshalText(p[:], text)
+}
+
+// MarshalJSON/UnmarshalJSON encode these types as the quoted 0x-prefixed
+// hex string that MarshalText produces, and decode it via UnmarshalText.
+func (pub EthBlsPubKey) MarshalJSON() ([]byte, error) {
+	text, err := pub.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+func (pub *EthBlsPubKey) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return pub.UnmarshalText([]byte(s))
+}
+
+func (sig EthBlsSignature) MarshalJSON() ([]byte, error) {
+	text, err := sig.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+func (sig *EthBlsSignature) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return sig.UnmarshalText([]byte(s))
+}
+
+func (c EthKzgCommitment) MarshalJSON() ([]byte, error) {
+	text, err := c.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+func (c *EthKzgCommitment) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return c.UnmarshalText([]byte(s))
+}
+
+func (p EthKzgProof) MarshalJSON() ([]byte, error) {
+	text, err := p.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+func (p *EthKzgProof) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return p.UnmarshalText([]byte(s))
+}
@@ -56,7 +56,7 @@ type (
 )
 
 type EthKzgContext struct {
-	cCtx *C.ctt_eth_kzg_context
+	cCtx       *C.ctt_eth_kzg_context
 	threadpool Threadpool
 }
 
@@ -77,6 +77,12 @@ func EthKzgContextNew(trustedSetupFile string) (ctx EthKzgContext, err error) {
 	return ctx, err
 }
 
+// SetThreadpool configures the threadpool used by the *Parallel methods.
+//
+// Deprecated: calling *Parallel methods after SetThreadpool relies on the
+// caller correctly managing runtime.LockOSThread themselves, which is easy
+// to get wrong (see ThreadpoolScope). Prefer Threadpool.Enter/Run and the
+// methods on the returned ThreadpoolScope instead.
 func (ctx *EthKzgContext) SetThreadpool(tp Threadpool) {
 	ctx.threadpool = tp
 }
@@ -309,7 +315,7 @@ func (ctx EthKzgContext) VerifyBlobKzgProofBatchParallel(blobs []EthBlob, commit
 // Ethereum BLS signatures
 // -----------------------------------------------------
 
-func getAddr[T any](arg []T) (unsafe.Pointer) {
+func getAddr[T any](arg []T) unsafe.Pointer {
 	// Makes sure to not access a non existant 0 element if the slice is empty
 	if len(arg) > 0 {
 		return unsafe.Pointer(&arg[0])
@@ -318,19 +324,17 @@ func getAddr[T any](arg []T) (unsafe.Pointer) {
 	}
 }
 
-
 type (
 	EthBlsSecKey    C.ctt_eth_bls_seckey
 	EthBlsPubKey    C.ctt_eth_bls_pubkey
 	EthBlsSignature C.ctt_eth_bls_signature
 )
 
-
 // Several byte array aliases used for BLS sigs and EVM prec.
 type (
-	Bytes32         [32]byte // serialized secret key
-	Bytes48         [48]byte // compressed, serialized public key
-	Bytes96         [96]byte // compressed, serialized signature
+	Bytes32 [32]byte // serialized secret key
+	Bytes48 [48]byte // compressed, serialized public key
+	Bytes96 [96]byte // compressed, serialized signature
 )
 
 func (pub EthBlsPubKey) IsZero() bool {
@@ -553,6 +557,7 @@ func FastAggregateVerify(pubkeys []EthBlsPubKey, message []byte, aggregate_sig E
 type ethBlsBatchSigAccumulator struct {
 	ctx *C.ctt_eth_bls_batch_sig_accumulator
 }
+
 func ethBlsBatchSigAccumulatorAlloc() (accum ethBlsBatchSigAccumulator) {
 	accum.ctx = C.ctt_eth_bls_alloc_batch_sig_accumulator()
 	return accum
@@ -587,7 +592,6 @@ func (accum ethBlsBatchSigAccumulator) finalVerify() bool {
 	return bool(status)
 }
 
-
 func BatchVerifySoA(pubkeys []EthBlsPubKey, messages [][]byte, signatures []EthBlsSignature, secureRandomBytes Bytes32) (bool, error) {
 	if len(pubkeys) == 0 {
 		err := errors.New(
@@ -647,9 +651,9 @@ func BatchVerifySoA(pubkeys []EthBlsPubKey, messages [][]byte, signatures []EthB
 }
 
 type BatchVerifyTriplet struct {
-	pub EthBlsPubKey
+	pub     EthBlsPubKey
 	message []byte
-	sig EthBlsSignature
+	sig     EthBlsSignature
 }
 
 func BatchVerifyAoS(triplets []BatchVerifyTriplet, secureRandomBytes Bytes32) (bool, error) {
@@ -696,12 +700,11 @@ func BatchVerifyAoS(triplets []BatchVerifyTriplet, secureRandomBytes Bytes32) (b
 // --------------------------------
 
 type (
-	Bytes64     [64]byte
-	Bytes128    [128]byte
-	Bytes256    [256]byte
+	Bytes64  [64]byte
+	Bytes128 [128]byte
+	Bytes256 [256]byte
 )
 
-
 func EvmSha256(inputs []byte) (result Bytes32, err error) {
 	status := C.ctt_eth_evm_sha256((*C.byte)(&result[0]),
 		32,
@@ -10,7 +10,10 @@
 package constantine
 
 import (
+	"bytes"
+	"context"
 	"crypto/rand"
+	"encoding/binary"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -35,6 +38,57 @@ func TestThreadpool(t *testing.T) {
 	tp.Shutdown()
 }
 
+// Deterministic batch-verification randomness
+// ----------------------------------------------------------
+
+func TestKzgBatchTranscriptDeterministic(t *testing.T) {
+	var commitment EthKzgCommitment
+	var proof EthKzgProof
+	var blob EthBlob
+
+	t1 := NewKzgBatchTranscript()
+	t1.AppendBatch([]EthBlob{blob}, []EthKzgCommitment{commitment}, []EthKzgProof{proof})
+
+	t2 := NewKzgBatchTranscript()
+	t2.AppendBatch([]EthBlob{blob}, []EthKzgCommitment{commitment}, []EthKzgProof{proof})
+
+	require.Equal(t, t1.Challenge(), t2.Challenge())
+
+	t3 := NewKzgBatchTranscript()
+	t3.Bind([]byte("slot 42"))
+	t3.AppendBatch([]EthBlob{blob}, []EthKzgCommitment{commitment}, []EthKzgProof{proof})
+
+	require.NotEqual(t, t1.Challenge(), t3.Challenge())
+}
+
+// Streaming Blob ingestion
+// ----------------------------------------------------------
+
+func TestBlobBuilder(t *testing.T) {
+	b := NewBlobBuilder()
+	var fe [32]byte // zero is a canonical field element
+	for i := 0; i < FieldElementsPerBlob; i++ {
+		require.NoError(t, b.AppendFieldElement(fe))
+	}
+	require.Equal(t, FieldElementsPerBlob, b.Len())
+
+	blob, err := b.Finalize()
+	require.NoError(t, err)
+	require.Equal(t, EthBlob{}, blob)
+
+	require.ErrorIs(t, b.AppendFieldElement(fe), ErrBlobBuilderFull)
+}
+
+func TestBlobBuilderNonCanonical(t *testing.T) {
+	b := NewBlobBuilder()
+	var fe [32]byte
+	fe[31] = 0xff // larger than the BLS12-381 scalar field modulus
+	require.ErrorIs(t, b.AppendFieldElement(fe), ErrNonCanonicalFieldElement)
+
+	_, err := b.Finalize()
+	require.ErrorIs(t, err, ErrBlobBuilderNotFull)
+}
+
 // Ethereum EIP-4844 KZG tests
 // ----------------------------------------------------------
 //
@@ -85,6 +139,108 @@ func (dst *EthKzgEvalAtChallenge) UnmarshalText(input []byte) error {
 	return fromHexImpl(dst[:], input)
 }
 
+func TestEthKzgContextNewFromReader(t *testing.T) {
+	f, err := os.Open(trustedSetupFile)
+	require.NoError(t, err)
+	defer f.Close()
+
+	ctx, err := EthKzgContextNewFromReader(f, 0)
+	require.NoError(t, err)
+	defer ctx.Delete()
+
+	refCtx, err := EthKzgContextNew(trustedSetupFile)
+	require.NoError(t, err)
+	defer refCtx.Delete()
+
+	var blob EthBlob
+	commitment, err := ctx.BlobToKzgCommitment(blob)
+	require.NoError(t, err)
+	refCommitment, err := refCtx.BlobToKzgCommitment(blob)
+	require.NoError(t, err)
+	require.Equal(t, refCommitment[:], commitment[:])
+}
+
+func TestEthKzgContextNewFromBytes(t *testing.T) {
+	data, err := os.ReadFile(trustedSetupFile)
+	require.NoError(t, err)
+
+	ctx, err := EthKzgContextNewFromBytes(data, TSFormatCKZG4844, 0)
+	require.NoError(t, err)
+	defer ctx.Delete()
+
+	refCtx, err := EthKzgContextNew(trustedSetupFile)
+	require.NoError(t, err)
+	defer refCtx.Delete()
+
+	var blob EthBlob
+	commitment, err := ctx.BlobToKzgCommitment(blob)
+	require.NoError(t, err)
+	refCommitment, err := refCtx.BlobToKzgCommitment(blob)
+	require.NoError(t, err)
+	require.Equal(t, refCommitment[:], commitment[:])
+}
+
+func TestEthKzgContextNewFromJSONMalformed(t *testing.T) {
+	_, err := EthKzgContextNewFromJSON(strings.NewReader("not json"), 0)
+	require.Error(t, err)
+
+	// Missing setup_G1/setup_G2: valid JSON, but not a complete trusted setup.
+	_, err = EthKzgContextNewFromJSON(strings.NewReader(`{"setup_G1_lagrange":["0x00"]}`), 0)
+	require.Error(t, err)
+}
+
+func TestBlobToKzgCommitmentFromReader(t *testing.T) {
+	ctx, tsErr := EthKzgContextNew(trustedSetupFile)
+	require.NoError(t, tsErr)
+	defer ctx.Delete()
+
+	var blob EthBlob
+	refCommitment, err := ctx.BlobToKzgCommitment(blob)
+	require.NoError(t, err)
+
+	commitment, err := ctx.BlobToKzgCommitmentFromReader(bytes.NewReader(blob[:]))
+	require.NoError(t, err)
+	require.Equal(t, refCommitment[:], commitment[:])
+
+	_, err = ctx.BlobToKzgCommitmentFromReader(bytes.NewReader(blob[:len(blob)-1]))
+	require.Error(t, err) // truncated reader: io.ReadFull surfaces io.ErrUnexpectedEOF
+
+	nonCanonical := blob
+	nonCanonical[31] = 0xff // larger than the BLS12-381 scalar field modulus
+	_, err = ctx.BlobToKzgCommitmentFromReader(bytes.NewReader(nonCanonical[:]))
+	require.ErrorIs(t, err, ErrNonCanonicalFieldElement)
+}
+
+func TestVerifyBlobKzgProofFromBytes(t *testing.T) {
+	ctx, tsErr := EthKzgContextNew(trustedSetupFile)
+	require.NoError(t, tsErr)
+	defer ctx.Delete()
+
+	var blob EthBlob
+	commitment, err := ctx.BlobToKzgCommitment(blob)
+	require.NoError(t, err)
+	proof, err := ctx.ComputeBlobKzgProof(blob, commitment)
+	require.NoError(t, err)
+
+	valid, err := ctx.VerifyBlobKzgProofFromBytes(blob[:], commitment, proof)
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	_, err = ctx.VerifyBlobKzgProofFromBytes(blob[:len(blob)-1], commitment, proof)
+	require.ErrorIs(t, err, ErrBlobLength)
+
+	nonCanonical := append([]byte(nil), blob[:]...)
+	nonCanonical[31] = 0xff // larger than the BLS12-381 scalar field modulus
+	_, err = ctx.VerifyBlobKzgProofFromBytes(nonCanonical, commitment, proof)
+	require.ErrorIs(t, err, ErrNonCanonicalFieldElement)
+
+	wrongBlob := append([]byte(nil), blob[:]...)
+	wrongBlob[32] = 0x01 // still canonical, but a different field element
+	valid, err = ctx.VerifyBlobKzgProofFromBytes(wrongBlob, commitment, proof)
+	require.NoError(t, err)
+	require.False(t, valid)
+}
+
 func TestBlobToKzgCommitment(t *testing.T) {
 	type Test struct {
 		Input struct {
@@ -443,6 +599,207 @@ func TestVerifyBlobKzgProofBatch(t *testing.T) {
 	}
 }
 
+// Ethereum EIP-7594 PeerDAS KZG tests
+// ----------------------------------------------------------
+//
+// Source: https://github.com/ethereum/c-kzg-4844
+
+var (
+	testDirPeerDAS                = "../tests/protocol_ethereum_eip7594_peerdas_kzg"
+	computeCellsAndKzgProofsTests = filepath.Join(testDirPeerDAS, "compute_cells_and_kzg_proofs/*/*/*")
+	recoverCellsAndKzgProofsTests = filepath.Join(testDirPeerDAS, "recover_cells_and_kzg_proofs/*/*/*")
+	verifyCellKzgProofBatchTests  = filepath.Join(testDirPeerDAS, "verify_cell_kzg_proof_batch/*/*/*")
+)
+
+func (dst *EthKzgCell) UnmarshalText(input []byte) error {
+	return fromHexImpl(dst[:], input)
+}
+
+func TestComputeCellsAndKzgProofs(t *testing.T) {
+	type Test struct {
+		Input struct {
+			Blob string `yaml:"blob"`
+		}
+		Output *[][]string `yaml:"output"`
+	}
+
+	ctx, tsErr := EthKzgContextNew(trustedSetupFile)
+	require.NoError(t, tsErr)
+	defer ctx.Delete()
+
+	tests, err := filepath.Glob(computeCellsAndKzgProofsTests)
+	require.NoError(t, err)
+	require.True(t, len(tests) > 0)
+
+	for _, testPath := range tests {
+		t.Run(testPath, func(t *testing.T) {
+			testFile, err := os.Open(testPath)
+			require.NoError(t, err)
+			test := Test{}
+			err = yaml.NewDecoder(testFile).Decode(&test)
+			require.NoError(t, testFile.Close())
+			require.NoError(t, err)
+
+			var blob EthBlob
+			err = blob.UnmarshalText([]byte(test.Input.Blob))
+			if err != nil {
+				require.Nil(t, test.Output)
+				return
+			}
+
+			cells, proofs, err := ctx.ComputeCellsAndKzgProofs(blob)
+			if err == nil {
+				require.NotNil(t, test.Output)
+				expectedCells := (*test.Output)[0]
+				expectedProofs := (*test.Output)[1]
+				for i := range cells {
+					var expectedCell EthKzgCell
+					err = expectedCell.UnmarshalText([]byte(expectedCells[i]))
+					require.NoError(t, err)
+					require.Equal(t, expectedCell[:], cells[i][:])
+
+					var expectedProof EthKzgProof
+					err = expectedProof.UnmarshalText([]byte(expectedProofs[i]))
+					require.NoError(t, err)
+					require.Equal(t, expectedProof[:], proofs[i][:])
+				}
+			} else {
+				require.Nil(t, test.Output)
+			}
+		})
+	}
+}
+
+func TestRecoverCellsAndKzgProofs(t *testing.T) {
+	type Test struct {
+		Input struct {
+			CellIndices []uint64 `yaml:"cell_indices"`
+			Cells       []string `yaml:"cells"`
+		}
+		Output *[][]string `yaml:"output"`
+	}
+
+	ctx, tsErr := EthKzgContextNew(trustedSetupFile)
+	require.NoError(t, tsErr)
+	defer ctx.Delete()
+
+	tests, err := filepath.Glob(recoverCellsAndKzgProofsTests)
+	require.NoError(t, err)
+	require.True(t, len(tests) > 0)
+
+	for _, testPath := range tests {
+		t.Run(testPath, func(t *testing.T) {
+			testFile, err := os.Open(testPath)
+			require.NoError(t, err)
+			test := Test{}
+			err = yaml.NewDecoder(testFile).Decode(&test)
+			require.NoError(t, testFile.Close())
+			require.NoError(t, err)
+
+			cells := make([]EthKzgCell, len(test.Input.Cells))
+			for i, c := range test.Input.Cells {
+				err = cells[i].UnmarshalText([]byte(c))
+				if err != nil {
+					require.Nil(t, test.Output)
+					return
+				}
+			}
+
+			recovered, proofs, err := ctx.RecoverCellsAndKzgProofs(test.Input.CellIndices, cells)
+			if err == nil {
+				require.NotNil(t, test.Output)
+				expectedCells := (*test.Output)[0]
+				expectedProofs := (*test.Output)[1]
+				for i := range recovered {
+					var expectedCell EthKzgCell
+					err = expectedCell.UnmarshalText([]byte(expectedCells[i]))
+					require.NoError(t, err)
+					require.Equal(t, expectedCell[:], recovered[i][:])
+
+					var expectedProof EthKzgProof
+					err = expectedProof.UnmarshalText([]byte(expectedProofs[i]))
+					require.NoError(t, err)
+					require.Equal(t, expectedProof[:], proofs[i][:])
+				}
+			} else {
+				require.Nil(t, test.Output)
+			}
+		})
+	}
+}
+
+func TestVerifyCellKzgProofBatch(t *testing.T) {
+	type Test struct {
+		Input struct {
+			Commitments []string `yaml:"commitments"`
+			CellIndices []uint64 `yaml:"cell_indices"`
+			Cells       []string `yaml:"cells"`
+			Proofs      []string `yaml:"proofs"`
+		}
+		Output *bool `yaml:"output"`
+	}
+
+	ctx, tsErr := EthKzgContextNew(trustedSetupFile)
+	require.NoError(t, tsErr)
+	defer ctx.Delete()
+
+	tests, err := filepath.Glob(verifyCellKzgProofBatchTests)
+	require.NoError(t, err)
+	require.True(t, len(tests) > 0)
+
+	for _, testPath := range tests {
+		t.Run(testPath, func(t *testing.T) {
+			testFile, err := os.Open(testPath)
+			require.NoError(t, err)
+			test := Test{}
+			err = yaml.NewDecoder(testFile).Decode(&test)
+			require.NoError(t, testFile.Close())
+			require.NoError(t, err)
+
+			var commitments []EthKzgCommitment
+			for _, c := range test.Input.Commitments {
+				var commitment EthKzgCommitment
+				err = commitment.UnmarshalText([]byte(c))
+				if err != nil {
+					require.Nil(t, test.Output)
+					return
+				}
+				commitments = append(commitments, commitment)
+			}
+
+			cells := make([]EthKzgCell, len(test.Input.Cells))
+			for i, c := range test.Input.Cells {
+				err = cells[i].UnmarshalText([]byte(c))
+				if err != nil {
+					require.Nil(t, test.Output)
+					return
+				}
+			}
+
+			var proofs []EthKzgProof
+			for _, p := range test.Input.Proofs {
+				var proof EthKzgProof
+				err = proof.UnmarshalText([]byte(p))
+				if err != nil {
+					require.Nil(t, test.Output)
+					return
+				}
+				proofs = append(proofs, proof)
+			}
+
+			valid, err := ctx.VerifyCellKzgProofBatch(commitments, test.Input.CellIndices, cells, proofs)
+			if err == nil {
+				require.NotNil(t, test.Output)
+				require.Equal(t, *test.Output, valid)
+			} else {
+				if test.Output != nil {
+					require.Equal(t, *test.Output, valid)
+				}
+			}
+		})
+	}
+}
+
 // Ethereum EIP-4844 KZG tests - Parallel
 // ----------------------------------------------------------
 
@@ -761,6 +1118,69 @@ func TestVerifyBlobKzgProofBatchParallel(t *testing.T) {
 	}
 }
 
+func TestVerifyBlobKzgProofBatchParallelCtx(t *testing.T) {
+	ctx, tsErr := EthKzgContextNew(trustedSetupFile)
+	require.NoError(t, tsErr)
+	defer ctx.Delete()
+
+	tp := createTestThreadpool(t)
+	ctx.SetThreadpool(tp)
+
+	var blob EthBlob
+	commitment, err := ctx.BlobToKzgCommitment(blob)
+	require.NoError(t, err)
+	proof, err := ctx.ComputeBlobKzgProof(blob, commitment)
+	require.NoError(t, err)
+
+	var secureRandomBytes [32]byte
+	_, _ = rand.Read(secureRandomBytes[:])
+
+	var progressCalls []int
+	onProgress := func(done, total int) { progressCalls = append(progressCalls, done) }
+
+	valid, err := ctx.VerifyBlobKzgProofBatchParallelCtx(
+		context.Background(),
+		[]EthBlob{blob}, []EthKzgCommitment{commitment}, []EthKzgProof{proof},
+		secureRandomBytes, onProgress,
+	)
+	require.NoError(t, err)
+	require.True(t, valid)
+	require.Equal(t, []int{0, 1}, progressCalls)
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = ctx.VerifyBlobKzgProofBatchParallelCtx(
+		cancelled,
+		[]EthBlob{blob}, []EthKzgCommitment{commitment}, []EthKzgProof{proof},
+		secureRandomBytes, nil,
+	)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestComputeCellsAndKzgProofsParallelCtx(t *testing.T) {
+	ctx, tsErr := EthKzgContextNew(trustedSetupFile)
+	require.NoError(t, tsErr)
+	defer ctx.Delete()
+
+	tp := createTestThreadpool(t)
+	ctx.SetThreadpool(tp)
+
+	var blob EthBlob
+
+	cells, proofs, err := ctx.ComputeCellsAndKzgProofsParallelCtx(context.Background(), blob, nil)
+	require.NoError(t, err)
+
+	refCells, refProofs, err := ctx.ComputeCellsAndKzgProofsParallel(blob)
+	require.NoError(t, err)
+	require.Equal(t, refCells, cells)
+	require.Equal(t, refProofs, proofs)
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err = ctx.ComputeCellsAndKzgProofsParallelCtx(cancelled, blob, nil)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
 // To be removed. This is the C example ported
 func TestExampleCBlsSig(t *testing.T) {
 	str := "Security pb becomes key mgmt pb!"
@@ -1040,7 +1460,6 @@ func TestVerify(t *testing.T) {
 				return
 			}
 
-
 			// Test checks
 			var status bool
 			pk, err := DeserializePubKeyCompressed(Bytes48(rawPk))
@@ -1164,90 +1583,214 @@ func TestFastAggregateVerify(t *testing.T) {
 	}
 }
 
-// NOTE: The aggregate verify test case is currently not used, because at the moment
-// we don't wrap aggregate test. It requires to expose the `BLSAggregateSigAccumulator`
-// type from Nim to C similarly to the batch sig accumulator. Once we have done so
-// we'll add back the test.
+func TestAggregateVerify(t *testing.T) {
+	type Test struct {
+		Input struct {
+			PubKeys   []string `json:"pubkeys"`
+			Messages  []string `json:"messages"`
+			Signature string   `json:"signature"`
+		} `json:"input"`
+		Output bool `json:"output"`
+	}
 
-//func TestAggregateVerify(t *testing.T) {
-//	type Test struct {
-//		Input struct {
-//			PubKeys []string `json:"pubkeys"`
-//			Messages []string `json:"messages"`
-//			Signature string `json:"signature"`
-//
-//		} `json:"input"`
-//		Output bool `json:"output"`
-//	}
-//
-//	tests, _ := filepath.Glob(aggregate_verifyTests)
-//	for _, testPath := range tests {
-//		t.Run(testPath, func(t *testing.T) {
-//			testFile, err := os.Open(testPath)
-//			test := Test{}
-//			err = json.NewDecoder(testFile).Decode(&test)
-//
-//			var rawPks []EthBlsPubKeyRaw
-//			for _, s := range test.Input.PubKeys {
-//				var rawPk EthBlsPubKeyRaw
-//				err = rawPk.UnmarshalText([]byte(s))
-//				if err != nil {
-//					require.Nil(t, test.Output)
-//					return
-//				}
-//				rawPks = append(rawPks, rawPk)
-//			}
-//			var rawSig EthBlsSignatureRaw
-//			err = rawSig.UnmarshalText([]byte(test.Input.Signature))
-//			if err != nil {
-//				require.False(t, test.Output) // tampered signaure test
-//				return
-//			}
-//
-//			var status bool
-//			{ // testChecks
-//				var pks []EthBlsPubKey
-//				for _, rawPk := range rawPks {
-//					var pk EthBlsPubKey
-//					status, err = pk.DeserializeCompressed(rawPk)
-//					if err != nil {
-//						require.Equal(t, status, test.Output)
-//						return
-//					}
-//					pks = append(pks, pk)
-//				}
-//				var sig EthBlsSignature
-//				status, err = sig.DeserializeCompressed(rawSig)
-//				if err != nil {
-//					require.Equal(t, status, test.Output)
-//					return
-//				}
-//				var msgs [][]byte
-//				for _, rawMsg := range test.Input.Messages {
-//					var msg EthBlsMessage
-//					err = msg.UnmarshalText([]byte(rawMsg))
-//					if err != nil {
-//						require.Nil(t, test.Output)
-//						return
-//					}
-//					msgs = append(msgs, msg[:])
-//				}
-//				status, err = AggregateVerify(pks, msgs[:], sig)
-//
-//				// And now the Go version
-//				status, err = AggregateVerifyGo(pks, msgs[:], sig)
-//			}
-//			require.Equal(t, status, test.Output)
-//			if status != test.Output {
-//				fmt.Println("Verification differs from expected \n",
-//				    "   valid sig? ", status, "\n",
-//				    "   expected: ", test.Output,
-//				)
-//				return
-//			}
-//		})
-//	}
-//}
+	tests, _ := filepath.Glob(aggregate_verifyTests)
+	for _, testPath := range tests {
+		t.Run(testPath, func(t *testing.T) {
+			testFile, err := os.Open(testPath)
+			test := Test{}
+			err = json.NewDecoder(testFile).Decode(&test)
+
+			var rawPks []EthBlsPubKeyRaw
+			for _, s := range test.Input.PubKeys {
+				var rawPk EthBlsPubKeyRaw
+				err = rawPk.UnmarshalText([]byte(s))
+				if err != nil {
+					require.Nil(t, test.Output)
+					return
+				}
+				rawPks = append(rawPks, rawPk)
+			}
+			var rawSig EthBlsSignatureRaw
+			err = rawSig.UnmarshalText([]byte(test.Input.Signature))
+			if err != nil {
+				require.False(t, test.Output) // tampered signaure test
+				return
+			}
+
+			var status bool
+			{ // testChecks
+				var pks []EthBlsPubKey
+				for _, rawPk := range rawPks {
+					pk, err := DeserializePubKeyCompressed(Bytes48(rawPk))
+					if err != nil {
+						require.Equal(t, status, test.Output)
+						return
+					}
+					pks = append(pks, pk)
+				}
+				sig, err := DeserializeSignatureCompressed(Bytes96(rawSig))
+				if err != nil {
+					require.Equal(t, status, test.Output)
+					return
+				}
+				var msgs [][]byte
+				for _, rawMsg := range test.Input.Messages {
+					var msg EthBlsMessage
+					err = msg.UnmarshalText([]byte(rawMsg))
+					if err != nil {
+						require.Nil(t, test.Output)
+						return
+					}
+					msgs = append(msgs, msg[:])
+				}
+				status, err = AggregateVerify(pks, msgs[:], sig)
+				require.Equal(t, status, test.Output)
+
+				// And now the Go version
+				status, err = AggregateVerifyGo(pks, msgs[:], sig)
+			}
+			require.Equal(t, status, test.Output)
+			if status != test.Output {
+				fmt.Println("Verification differs from expected \n",
+					"   valid sig? ", status, "\n",
+					"   expected: ", test.Output,
+				)
+				return
+			}
+		})
+	}
+}
+
+func TestAggregatePubKeysAndSignatures(t *testing.T) {
+	const n = 4
+	pubkeys := make([]EthBlsPubKey, n)
+	sigs := make([]EthBlsSignature, n)
+	msg := sha256.Hash([]byte("aggregate pubkeys and signatures"), false)
+
+	for i := 0; i < n; i++ {
+		var rawSecKey [32]byte
+		binary.LittleEndian.PutUint64(rawSecKey[24:], uint64(i)+1)
+		secKey, err := DeserializeSecKey(rawSecKey)
+		require.NoError(t, err)
+
+		pubkeys[i] = DerivePubKey(secKey)
+		sigs[i] = Sign(secKey, msg[:])
+	}
+
+	aggPub, err := AggregatePubKeys(pubkeys)
+	require.NoError(t, err)
+	aggSig, err := AggregateSignatures(sigs)
+	require.NoError(t, err)
+
+	status, err := aggPub.Verify(msg[:], aggSig)
+	require.NoError(t, err)
+	require.True(t, status)
+
+	status, err = FastAggregateVerify(pubkeys, msg[:], aggSig)
+	require.NoError(t, err)
+	require.True(t, status)
+}
+
+func TestEthBlsEncodingRoundTrip(t *testing.T) {
+	var rawSecKey [32]byte
+	rawSecKey[31] = 0x2a
+	secKey, err := DeserializeSecKey(rawSecKey)
+	require.NoError(t, err)
+
+	pub := DerivePubKey(secKey)
+	msg := sha256.Hash([]byte("encoding round trip"), false)
+	sig := Sign(secKey, msg[:])
+
+	{ // encoding.BinaryMarshaler / BinaryUnmarshaler
+		b, err := pub.MarshalBinary()
+		require.NoError(t, err)
+		var decoded EthBlsPubKey
+		require.NoError(t, decoded.UnmarshalBinary(b))
+		require.True(t, pub.AreEqual(decoded))
+	}
+	{ // encoding.TextMarshaler / TextUnmarshaler: 0x-prefixed hex
+		text, err := sig.MarshalText()
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(string(text), "0x"))
+		var decoded EthBlsSignature
+		require.NoError(t, decoded.UnmarshalText(text))
+		require.True(t, sig.AreEqual(decoded))
+	}
+	{ // json.Marshaler / Unmarshaler
+		commitment := EthKzgCommitment{1, 2, 3}
+		data, err := json.Marshal(&commitment)
+		require.NoError(t, err)
+		var decoded EthKzgCommitment
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		require.Equal(t, commitment, decoded)
+	}
+}
+
+// A pointer-receiver-only Marshaler silently falls back to encoding/json's
+// default struct/array encoding for a non-addressable value instead of
+// erroring, so it's worth proving these types still encode as a quoted hex
+// string when json.Marshal only ever sees a value, not a pointer: a map
+// value and a boxed interface{} are the two shapes that trip this up.
+func TestEthBlsEncodingMarshalByValue(t *testing.T) {
+	commitment := EthKzgCommitment{1, 2, 3}
+
+	want, err := json.Marshal(&commitment)
+	require.NoError(t, err)
+
+	byValue, err := json.Marshal(commitment)
+	require.NoError(t, err)
+	require.Equal(t, want, byValue)
+
+	inMap, err := json.Marshal(map[string]EthKzgCommitment{"c": commitment})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"c":`+string(want)+`}`, string(inMap))
+
+	var boxed interface{} = commitment
+	inInterface, err := json.Marshal(boxed)
+	require.NoError(t, err)
+	require.Equal(t, want, inInterface)
+}
+
+func TestBatchVerifySoAParallel(t *testing.T) {
+	// Don't use t.Run() with parallel C code to not mess up thread-local storage
+	tp := createTestThreadpool(t)
+
+	pks, msgs, sigs := genBatchVerifyInputs(t, 16)
+	var randomBytes [32]byte
+
+	status, err := BatchVerifySoAParallel(tp, pks, msgs, sigs, randomBytes)
+	require.NoError(t, err)
+	require.True(t, status)
+
+	trp := make([]BatchVerifyTriplet, len(pks))
+	for i := range trp {
+		trp[i] = BatchVerifyTriplet{pub: pks[i], message: msgs[i], sig: sigs[i]}
+	}
+	status, err = BatchVerifyAoSParallel(tp, trp, randomBytes)
+	require.NoError(t, err)
+	require.True(t, status)
+}
+
+func TestBlsBatchVerifierMerge(t *testing.T) {
+	pks, msgs, sigs := genBatchVerifyInputs(t, 8)
+	var randomBytes [32]byte
+
+	left := NewBlsBatchVerifier(randomBytes, []byte("shard-0"))
+	defer left.Free()
+	right := NewBlsBatchVerifier(randomBytes, []byte("shard-1"))
+	defer right.Free()
+
+	mid := len(pks) / 2
+	for i := 0; i < mid; i++ {
+		require.NoError(t, left.Update(pks[i], msgs[i], sigs[i]))
+	}
+	for i := mid; i < len(pks); i++ {
+		require.NoError(t, right.Update(pks[i], msgs[i], sigs[i]))
+	}
+
+	require.NoError(t, left.Merge(right))
+	require.True(t, left.FinalVerify())
+}
 
 func TestBatchVerify(t *testing.T) {
 	type Test struct {
@@ -1331,10 +1874,10 @@ func TestBatchVerify(t *testing.T) {
 				status, err = BatchVerifyAoS(trp, randomBytes)
 				require.Equal(t, status, test.Output)
 
-				// TODO: The parallel API needs to be reimplemented using parallelism on the Go side
-				// and parallel API
-				// parallelStatus, _ := BatchVerifyParallel(tp, pks, msgs[:], sigs, randomBytes)
-				// require.Equal(t, parallelStatus, test.Output)
+				// And using the Go-side sharded parallel API
+				parallelStatus, err := BatchVerifyParallelSoA(nil, pks, msgs[:], sigs, randomBytes, 0)
+				require.NoError(t, err)
+				require.Equal(t, test.Output, parallelStatus)
 			}
 			require.Equal(t, status, test.Output)
 			if status != test.Output {
@@ -1348,6 +1891,140 @@ func TestBatchVerify(t *testing.T) {
 	}
 }
 
+func genBatchVerifyInputs(t require.TestingT, n int) ([]EthBlsPubKey, [][]byte, []EthBlsSignature) {
+	pks := make([]EthBlsPubKey, n)
+	msgs := make([][]byte, n)
+	sigs := make([]EthBlsSignature, n)
+	for i := 0; i < n; i++ {
+		var rawSecKey [32]byte
+		binary.LittleEndian.PutUint64(rawSecKey[24:], uint64(i)+1) // avoid the all-zero secret key
+		secKey, err := DeserializeSecKey(rawSecKey)
+		require.NoError(t, err)
+
+		pks[i] = DerivePubKey(secKey)
+		msg := sha256.Hash([]byte(fmt.Sprintf("benchmark message %d", i)), false)
+		msgs[i] = msg[:]
+		sigs[i] = Sign(secKey, msgs[i])
+	}
+	return pks, msgs, sigs
+}
+
+func benchmarkBatchVerifyParallel(b *testing.B, n int) {
+	pks, msgs, sigs := genBatchVerifyInputs(b, n)
+	var randomBytes [32]byte
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		valid, err := BatchVerifyParallelSoA(nil, pks, msgs, sigs, randomBytes, 0)
+		require.NoError(b, err)
+		require.True(b, valid)
+	}
+}
+
+func BenchmarkBatchVerifyParallel8(b *testing.B)    { benchmarkBatchVerifyParallel(b, 8) }
+func BenchmarkBatchVerifyParallel64(b *testing.B)   { benchmarkBatchVerifyParallel(b, 64) }
+func BenchmarkBatchVerifyParallel512(b *testing.B)  { benchmarkBatchVerifyParallel(b, 512) }
+func BenchmarkBatchVerifyParallel4096(b *testing.B) { benchmarkBatchVerifyParallel(b, 4096) }
+
+func TestDeserializeOptsDefaultMatchesStrict(t *testing.T) {
+	var rawSecKey [32]byte
+	rawSecKey[31] = 0x42
+	secKey, err := DeserializeSecKey(rawSecKey)
+	require.NoError(t, err)
+
+	pub := DerivePubKey(secKey)
+	msg := sha256.Hash([]byte("deserialize opts default"), false)
+	sig := Sign(secKey, msg[:])
+
+	pubBytes, err := pub.SerializeCompressed()
+	require.NoError(t, err)
+	sigBytes, err := sig.SerializeCompressed()
+	require.NoError(t, err)
+
+	strictPub, strictErr := DeserializePubKeyCompressed(pubBytes)
+	optsPub, optsErr := DeserializePubKeyCompressedOpts(pubBytes, DeserializeOpts{})
+	require.Equal(t, strictErr, optsErr)
+	require.True(t, strictPub.AreEqual(optsPub))
+
+	strictSig, strictErr := DeserializeSignatureCompressed(sigBytes)
+	optsSig, optsErr := DeserializeSignatureCompressedOpts(sigBytes, DeserializeOpts{})
+	require.Equal(t, strictErr, optsErr)
+	require.True(t, strictSig.AreEqual(optsSig))
+
+	valid, err := VerifyCompressed(pubBytes, msg[:], sigBytes, DeserializeOpts{})
+	require.NoError(t, err)
+	require.True(t, valid)
+}
+
+func TestDeserializeOptsSkipSubgroupCheckBatchVerify(t *testing.T) {
+	// A SkipSubgroupCheck deserialize is meant for the "KeyValidate once,
+	// verify many" pattern: a batch verify re-randomizes and re-checks the
+	// pairing equation for every triplet, so an out-of-subgroup signature
+	// that slipped past the cheaper codec path is still caught there.
+	//
+	// Unlike TestDeserializeOptsDefaultMatchesStrict, this property can't be
+	// exercised with a synthetic point built purely from this package's
+	// bindings: every exposed constructor (DerivePubKey, Sign, the
+	// EIP-2537 map-to-curve precompiles) clears the cofactor before
+	// returning, so it's infeasible to produce an on-curve-but-outside-the-
+	// subgroup point without either a real not-in-subgroup wire vector or
+	// lower-level curve arithmetic this package doesn't expose. So this
+	// test is still vector-driven, via the same JSON-vector harness used
+	// by TestDeserializeG2 (see deserialization_G2Tests); this snapshot
+	// ships no test vectors, so skip rather than silently no-op and claim
+	// coverage the test doesn't have.
+	tests, _ := filepath.Glob(filepath.Join(testDirBls, "deserialization_G2/*subgroup*"))
+	if len(tests) == 0 {
+		t.Skip("no not-in-subgroup deserialization_G2 test vectors available in this checkout")
+	}
+	for _, testPath := range tests {
+		t.Run(testPath, func(t *testing.T) {
+			type Test struct {
+				Input struct {
+					Signature string `json:"signature"`
+				} `json:"input"`
+				Output bool `json:"output"`
+			}
+			testFile, err := os.Open(testPath)
+			require.NoError(t, err)
+			test := Test{}
+			require.NoError(t, json.NewDecoder(testFile).Decode(&test))
+
+			var rawSig EthBlsSignatureRaw
+			require.NoError(t, rawSig.UnmarshalText([]byte(test.Input.Signature)))
+
+			// Plain deserialization rejects an out-of-subgroup signature.
+			_, err = DeserializeSignatureCompressed(Bytes96(rawSig))
+			require.Error(t, err)
+
+			// SkipSubgroupCheck lets it through the codec...
+			sig, err := DeserializeSignatureCompressedOpts(Bytes96(rawSig), DeserializeOpts{SkipSubgroupCheck: true})
+			require.NoError(t, err)
+
+			// ...but plain Verify, which checks one pairing equation, can
+			// still accept an off-subgroup point: callers must route
+			// untrusted SkipSubgroupCheck bytes through a batch verify.
+			var rawSecKey [32]byte
+			rawSecKey[31] = 0x07
+			secKey, err := DeserializeSecKey(rawSecKey)
+			require.NoError(t, err)
+			pub := DerivePubKey(secKey)
+			pubBytes, err := pub.SerializeCompressed()
+			require.NoError(t, err)
+			msg := sha256.Hash([]byte("subgroup check opts"), false)
+
+			randomBytes := sha256.Hash([]byte("totally non-secure source of entropy"), false)
+			status, err := BatchVerifySoACompressed(
+				[]Bytes48{pubBytes}, [][]byte{msg[:]}, []Bytes96{Bytes96(rawSig)},
+				randomBytes, DeserializeOpts{SkipSubgroupCheck: true},
+			)
+			require.NoError(t, err)
+			require.Equal(t, test.Output, status)
+			_ = sig
+		})
+	}
+}
+
 // --------------------------------
 // ------- EVM precompiles --------
 // --------------------------------
@@ -1541,7 +2218,6 @@ func runTestB64(t *testing.T, testPath string, fn TF64) {
 					require.True(t, false)
 				}
 
-
 				// Call the test function
 				r, err := fn(inputBytes)
 				if err != nil {
@@ -1685,6 +2361,93 @@ func TestFailMsmG2Bls(t *testing.T) {
 	runTestB256(t, fail_multiexp_G2_bls_tests, EvmBls12381G2Msm)
 }
 
+func TestMsmG2BlsInto(t *testing.T) {
+	tests, _ := filepath.Glob(multiexp_G2_bls_tests)
+	for _, testPath := range tests {
+		t.Run(testPath, func(t *testing.T) {
+			vectors, pStatus := loadVectors(testPath)
+			require.True(t, pStatus)
+			for _, vec := range vectors {
+				inputBytes, expectedBytes, status := parseTest(vec)
+				require.True(t, status)
+
+				r, err := EvmBls12381G2Msm(inputBytes)
+
+				var dst [256]byte
+				intoErr := EvmBls12381G2MsmInto(dst[:], inputBytes)
+				require.Equal(t, err == nil, intoErr == nil)
+				if err == nil {
+					require.Equal(t, r[:], dst[:])
+					require.Equal(t, expectedBytes[:], dst[:])
+				}
+			}
+		})
+	}
+}
+
+func TestMsmG2BlsBatch(t *testing.T) {
+	tests, _ := filepath.Glob(multiexp_G2_bls_tests)
+	for _, testPath := range tests {
+		t.Run(testPath, func(t *testing.T) {
+			vectors, pStatus := loadVectors(testPath)
+			require.True(t, pStatus)
+
+			inputs := make([][]byte, 0, len(vectors))
+			expected := make([]Bytes256, 0, len(vectors))
+			for _, vec := range vectors {
+				inputBytes, expectedBytes, status := parseTest(vec)
+				require.True(t, status)
+				if len(expectedBytes) == 0 {
+					continue // skip cases expected to fail: Batch reports a per-item error instead
+				}
+				inputs = append(inputs, inputBytes)
+				var exp Bytes256
+				copy(exp[:], expectedBytes)
+				expected = append(expected, exp)
+			}
+			if len(inputs) == 0 {
+				return
+			}
+
+			results := make([]Bytes256, len(inputs))
+			errs := EvmBls12381G2MsmBatch(inputs, results)
+			for i := range inputs {
+				require.NoError(t, errs[i])
+				require.Equal(t, expected[i][:], results[i][:])
+			}
+
+			ctxResults := make([]Bytes256, len(inputs))
+			ctxErrs, err := EvmBls12381G2MsmBatchCtx(context.Background(), inputs, ctxResults)
+			require.NoError(t, err)
+			for i := range inputs {
+				require.NoError(t, ctxErrs[i])
+				require.Equal(t, expected[i][:], ctxResults[i][:])
+			}
+		})
+	}
+}
+
+func TestEvmBls12381BatchCtxCancelled(t *testing.T) {
+	inputs := make([][]byte, 4)
+	results := make([]Bytes256, 4)
+
+	c, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := EvmBls12381G2MsmBatchCtx(c, inputs, results)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestEvmBls12381IntoDstTooSmall(t *testing.T) {
+	var tooSmall [255]byte
+	err := EvmBls12381G2MsmInto(tooSmall[:], nil)
+	require.Error(t, err)
+
+	var tooSmall32 [31]byte
+	err = EvmBls12381PairingCheckInto(tooSmall32[:], nil)
+	require.Error(t, err)
+}
+
 func TestPairingCheckBls(t *testing.T) {
 	runTestB32(t, pairing_check_bls_tests, EvmBls12381PairingCheck)
 }
@@ -0,0 +1,149 @@
+/** Constantine
+ *  Copyright (c) 2018-2019    Status Research & Development GmbH
+ *  Copyright (c) 2020-Present Mamy André-Ratsimbazafy
+ *  Licensed and distributed under either of
+ *    * MIT license (license terms in the root directory or at http://opensource.org/licenses/MIT).
+ *    * Apache v2 license (license terms in the root directory or at http://www.apache.org/licenses/LICENSE-2.0).
+ *  at your option. This file may not be copied, modified, or distributed except according to those terms.
+ */
+
+package constantine
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+)
+
+// Parallel batch API for the BLS12-381 MSM/pairing precompiles
+// -----------------------------------------------------
+//
+// A block replay calls EvmBls12381G1Msm/G2Msm/PairingCheck on many
+// independent inputs in a row. Each call already writes to its own output
+// buffer and reads its own input slice, so there's no cross-call state to
+// synchronize: a worker pool sized to runtime.GOMAXPROCS can simply run
+// them concurrently, one goroutine per slot, each pinning its own input
+// slice for the duration of its cgo call via the *Into variants.
+
+// runEvmBatch runs worker(i) for every i in [0, n) across a pool sized to
+// runtime.GOMAXPROCS, and collects one error per item.
+func runEvmBatch(n int, worker func(i int) error) []error {
+	errs := make([]error, n)
+	if n == 0 {
+		return errs
+	}
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > n {
+		workers = n
+	}
+
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				errs[i] = worker(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+
+	return errs
+}
+
+// runEvmBatchCtx is the context-cancellable counterpart of runEvmBatch: if c
+// is cancelled before the batch finishes, it returns immediately with
+// c.Err(). As with the *ParallelCtx KZG wrappers, cancellation here means
+// "stop waiting for the batch", not "abort every in-flight precompile
+// call" — the goroutines left running finish and their results are
+// discarded.
+func runEvmBatchCtx(c context.Context, n int, worker func(i int) error) ([]error, error) {
+	done := make(chan []error, 1)
+	go func() {
+		done <- runEvmBatch(n, worker)
+	}()
+
+	select {
+	case <-c.Done():
+		return nil, c.Err()
+	case errs := <-done:
+		return errs, nil
+	}
+}
+
+// EvmBls12381G1MsmBatch runs EvmBls12381G1MsmInto over inputs concurrently,
+// writing each result into the matching slot of results. len(inputs) must
+// equal len(results).
+func EvmBls12381G1MsmBatch(inputs [][]byte, results []Bytes128) []error {
+	if len(inputs) != len(results) {
+		return []error{errors.New("EvmBls12381G1MsmBatch: len(inputs) must equal len(results)")}
+	}
+	return runEvmBatch(len(inputs), func(i int) error {
+		return EvmBls12381G1MsmInto(results[i][:], inputs[i])
+	})
+}
+
+// EvmBls12381G1MsmBatchCtx is the context-cancellable counterpart of
+// EvmBls12381G1MsmBatch.
+func EvmBls12381G1MsmBatchCtx(c context.Context, inputs [][]byte, results []Bytes128) ([]error, error) {
+	if len(inputs) != len(results) {
+		return nil, errors.New("EvmBls12381G1MsmBatchCtx: len(inputs) must equal len(results)")
+	}
+	return runEvmBatchCtx(c, len(inputs), func(i int) error {
+		return EvmBls12381G1MsmInto(results[i][:], inputs[i])
+	})
+}
+
+// EvmBls12381G2MsmBatch runs EvmBls12381G2MsmInto over inputs concurrently,
+// writing each result into the matching slot of results. len(inputs) must
+// equal len(results).
+func EvmBls12381G2MsmBatch(inputs [][]byte, results []Bytes256) []error {
+	if len(inputs) != len(results) {
+		return []error{errors.New("EvmBls12381G2MsmBatch: len(inputs) must equal len(results)")}
+	}
+	return runEvmBatch(len(inputs), func(i int) error {
+		return EvmBls12381G2MsmInto(results[i][:], inputs[i])
+	})
+}
+
+// EvmBls12381G2MsmBatchCtx is the context-cancellable counterpart of
+// EvmBls12381G2MsmBatch.
+func EvmBls12381G2MsmBatchCtx(c context.Context, inputs [][]byte, results []Bytes256) ([]error, error) {
+	if len(inputs) != len(results) {
+		return nil, errors.New("EvmBls12381G2MsmBatchCtx: len(inputs) must equal len(results)")
+	}
+	return runEvmBatchCtx(c, len(inputs), func(i int) error {
+		return EvmBls12381G2MsmInto(results[i][:], inputs[i])
+	})
+}
+
+// EvmBls12381PairingCheckBatch runs EvmBls12381PairingCheckInto over inputs
+// concurrently, writing each result into the matching slot of results.
+// len(inputs) must equal len(results).
+func EvmBls12381PairingCheckBatch(inputs [][]byte, results []Bytes32) []error {
+	if len(inputs) != len(results) {
+		return []error{errors.New("EvmBls12381PairingCheckBatch: len(inputs) must equal len(results)")}
+	}
+	return runEvmBatch(len(inputs), func(i int) error {
+		return EvmBls12381PairingCheckInto(results[i][:], inputs[i])
+	})
+}
+
+// EvmBls12381PairingCheckBatchCtx is the context-cancellable counterpart of
+// EvmBls12381PairingCheckBatch.
+func EvmBls12381PairingCheckBatchCtx(c context.Context, inputs [][]byte, results []Bytes32) ([]error, error) {
+	if len(inputs) != len(results) {
+		return nil, errors.New("EvmBls12381PairingCheckBatchCtx: len(inputs) must equal len(results)")
+	}
+	return runEvmBatchCtx(c, len(inputs), func(i int) error {
+		return EvmBls12381PairingCheckInto(results[i][:], inputs[i])
+	})
+}
@@ -0,0 +1,194 @@
+/** Constantine
+ *  Copyright (c) 2018-2019    Status Research & Development GmbH
+ *  Copyright (c) 2020-Present Mamy André-Ratsimbazafy
+ *  Licensed and distributed under either of
+ *    * MIT license (license terms in the root directory or at http://opensource.org/licenses/MIT).
+ *    * Apache v2 license (license terms in the root directory or at http://www.apache.org/licenses/LICENSE-2.0).
+ *  at your option. This file may not be copied, modified, or distributed except according to those terms.
+ */
+
+package constantine
+
+/*
+#cgo CFLAGS: -I"${SRCDIR}/../include"
+#cgo !windows LDFLAGS: "${SRCDIR}/../lib/libconstantine.a"
+#cgo windows LDFLAGS: -L"${SRCDIR}/../lib" -Wl,-Bstatic -lconstantine -Wl,-Bdynamic
+
+#include <stdlib.h>
+#include <constantine.h>
+
+*/
+import "C"
+import (
+	"errors"
+	"fmt"
+)
+
+// Zero-allocation output-buffer variants of the EIP-2537 precompile bindings
+// -----------------------------------------------------
+//
+// EvmBls12381G1Add and its siblings below all return a stack-allocated
+// BytesN by value, which an EVM execution loop replaying millions of these
+// calls per block ends up copying and re-zeroing every time. The ...Into
+// variants write straight into a caller-supplied, caller-pooled dst slice
+// instead.
+
+func checkDstLen(dst []byte, want int, fn string) error {
+	if len(dst) < want {
+		return fmt.Errorf("%s: dst must be at least %d bytes long, got %d", fn, want, len(dst))
+	}
+	return nil
+}
+
+// EvmBls12381G1AddInto writes the EIP-2537 BLS12_G1ADD result into dst,
+// which must be at least 128 bytes long.
+func EvmBls12381G1AddInto(dst []byte, inputs []byte) error {
+	if err := checkDstLen(dst, 128, "EvmBls12381G1AddInto"); err != nil {
+		return err
+	}
+	status := C.ctt_eth_evm_bls12381_g1add((*C.byte)(&dst[0]),
+		128,
+		(*C.byte)(getAddr(inputs)),
+		(C.size_t)(len(inputs)),
+	)
+	if status != C.cttEVM_Success {
+		return errors.New(C.GoString(C.ctt_evm_status_to_string(status)))
+	}
+	return nil
+}
+
+// EvmBls12381G1MulInto writes the EIP-2537 BLS12_G1MUL result into dst,
+// which must be at least 128 bytes long.
+func EvmBls12381G1MulInto(dst []byte, inputs []byte) error {
+	if err := checkDstLen(dst, 128, "EvmBls12381G1MulInto"); err != nil {
+		return err
+	}
+	status := C.ctt_eth_evm_bls12381_g1mul((*C.byte)(&dst[0]),
+		128,
+		(*C.byte)(getAddr(inputs)),
+		(C.size_t)(len(inputs)),
+	)
+	if status != C.cttEVM_Success {
+		return errors.New(C.GoString(C.ctt_evm_status_to_string(status)))
+	}
+	return nil
+}
+
+// EvmBls12381G1MsmInto writes the EIP-2537 BLS12_G1MSM result into dst,
+// which must be at least 128 bytes long.
+func EvmBls12381G1MsmInto(dst []byte, inputs []byte) error {
+	if err := checkDstLen(dst, 128, "EvmBls12381G1MsmInto"); err != nil {
+		return err
+	}
+	status := C.ctt_eth_evm_bls12381_g1msm((*C.byte)(&dst[0]),
+		128,
+		(*C.byte)(getAddr(inputs)),
+		(C.size_t)(len(inputs)),
+	)
+	if status != C.cttEVM_Success {
+		return errors.New(C.GoString(C.ctt_evm_status_to_string(status)))
+	}
+	return nil
+}
+
+// EvmBls12381G2AddInto writes the EIP-2537 BLS12_G2ADD result into dst,
+// which must be at least 256 bytes long.
+func EvmBls12381G2AddInto(dst []byte, inputs []byte) error {
+	if err := checkDstLen(dst, 256, "EvmBls12381G2AddInto"); err != nil {
+		return err
+	}
+	status := C.ctt_eth_evm_bls12381_g2add((*C.byte)(&dst[0]),
+		256,
+		(*C.byte)(getAddr(inputs)),
+		(C.size_t)(len(inputs)),
+	)
+	if status != C.cttEVM_Success {
+		return errors.New(C.GoString(C.ctt_evm_status_to_string(status)))
+	}
+	return nil
+}
+
+// EvmBls12381G2MulInto writes the EIP-2537 BLS12_G2MUL result into dst,
+// which must be at least 256 bytes long.
+func EvmBls12381G2MulInto(dst []byte, inputs []byte) error {
+	if err := checkDstLen(dst, 256, "EvmBls12381G2MulInto"); err != nil {
+		return err
+	}
+	status := C.ctt_eth_evm_bls12381_g2mul((*C.byte)(&dst[0]),
+		256,
+		(*C.byte)(getAddr(inputs)),
+		(C.size_t)(len(inputs)),
+	)
+	if status != C.cttEVM_Success {
+		return errors.New(C.GoString(C.ctt_evm_status_to_string(status)))
+	}
+	return nil
+}
+
+// EvmBls12381G2MsmInto writes the EIP-2537 BLS12_G2MSM result into dst,
+// which must be at least 256 bytes long.
+func EvmBls12381G2MsmInto(dst []byte, inputs []byte) error {
+	if err := checkDstLen(dst, 256, "EvmBls12381G2MsmInto"); err != nil {
+		return err
+	}
+	status := C.ctt_eth_evm_bls12381_g2msm((*C.byte)(&dst[0]),
+		256,
+		(*C.byte)(getAddr(inputs)),
+		(C.size_t)(len(inputs)),
+	)
+	if status != C.cttEVM_Success {
+		return errors.New(C.GoString(C.ctt_evm_status_to_string(status)))
+	}
+	return nil
+}
+
+// EvmBls12381PairingCheckInto writes the EIP-2537 BLS12_PAIRING_CHECK result
+// into dst, which must be at least 32 bytes long.
+func EvmBls12381PairingCheckInto(dst []byte, inputs []byte) error {
+	if err := checkDstLen(dst, 32, "EvmBls12381PairingCheckInto"); err != nil {
+		return err
+	}
+	status := C.ctt_eth_evm_bls12381_pairingcheck((*C.byte)(&dst[0]),
+		32,
+		(*C.byte)(getAddr(inputs)),
+		(C.size_t)(len(inputs)),
+	)
+	if status != C.cttEVM_Success {
+		return errors.New(C.GoString(C.ctt_evm_status_to_string(status)))
+	}
+	return nil
+}
+
+// EvmBls12381MapFpToG1Into writes the EIP-2537 BLS12_MAP_FP_TO_G1 result
+// into dst, which must be at least 128 bytes long.
+func EvmBls12381MapFpToG1Into(dst []byte, inputs []byte) error {
+	if err := checkDstLen(dst, 128, "EvmBls12381MapFpToG1Into"); err != nil {
+		return err
+	}
+	status := C.ctt_eth_evm_bls12381_map_fp_to_g1((*C.byte)(&dst[0]),
+		128,
+		(*C.byte)(getAddr(inputs)),
+		(C.size_t)(len(inputs)),
+	)
+	if status != C.cttEVM_Success {
+		return errors.New(C.GoString(C.ctt_evm_status_to_string(status)))
+	}
+	return nil
+}
+
+// EvmBls12381MapFp2ToG2Into writes the EIP-2537 BLS12_MAP_FP2_TO_G2 result
+// into dst, which must be at least 256 bytes long.
+func EvmBls12381MapFp2ToG2Into(dst []byte, inputs []byte) error {
+	if err := checkDstLen(dst, 256, "EvmBls12381MapFp2ToG2Into"); err != nil {
+		return err
+	}
+	status := C.ctt_eth_evm_bls12381_map_fp2_to_g2((*C.byte)(&dst[0]),
+		256,
+		(*C.byte)(getAddr(inputs)),
+		(C.size_t)(len(inputs)),
+	)
+	if status != C.cttEVM_Success {
+		return errors.New(C.GoString(C.ctt_evm_status_to_string(status)))
+	}
+	return nil
+}
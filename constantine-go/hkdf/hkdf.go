@@ -0,0 +1,84 @@
+/** Constantine
+ *  Copyright (c) 2018-2019    Status Research & Development GmbH
+ *  Copyright (c) 2020-Present Mamy André-Ratsimbazafy
+ *  Licensed and distributed under either of
+ *    * MIT license (license terms in the root directory or at http://opensource.org/licenses/MIT).
+ *    * Apache v2 license (license terms in the root directory or at http://www.apache.org/licenses/LICENSE-2.0).
+ *  at your option. This file may not be copied, modified, or distributed except according to those terms.
+ */
+
+// Package hkdf implements RFC 5869 HKDF over Constantine's HMAC-SHA256, the
+// key-derivation primitive EIP-2333 BLS key derivation and TLS-1.3-style
+// post-handshake key schedules build on top of.
+package hkdf
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/mratsim/constantine/constantine-go/sha256"
+)
+
+const hashLen = sha256.Size
+
+// Extract derives a pseudorandom key from salt and ikm, per RFC 5869 §2.2.
+// If salt is empty, a zero-filled key of hashLen bytes is used, matching
+// the RFC's default.
+func Extract(salt, ikm []byte) []byte {
+	if len(salt) == 0 {
+		salt = make([]byte, hashLen)
+	}
+	mac := sha256.NewHMAC(salt)
+	mac.Write(ikm)
+	return mac.Sum(nil)
+}
+
+// Expand derives length bytes of output keying material from prk and info,
+// per RFC 5869 §2.3. prk should come from Extract (or otherwise already be
+// a uniformly random key of at least hashLen bytes).
+func Expand(prk, info []byte, length int) ([]byte, error) {
+	if length <= 0 {
+		return nil, errors.New("hkdf: length must be positive")
+	}
+	const maxLength = 255 * hashLen
+	if length > maxLength {
+		return nil, fmt.Errorf("hkdf: length must be at most %d", maxLength)
+	}
+
+	okm := make([]byte, 0, length)
+	var prev []byte
+	for i := byte(1); len(okm) < length; i++ {
+		mac := sha256.NewHMAC(prk)
+		mac.Write(prev)
+		mac.Write(info)
+		mac.Write([]byte{i})
+		prev = mac.Sum(nil)
+		okm = append(okm, prev...)
+	}
+	return okm[:length], nil
+}
+
+// ExpandLabel derives length bytes via the TLS-1.3-style HkdfLabel
+// construction (RFC 8446 §7.1): a two-byte length, a length-prefixed
+// "constantine <label>" string, and a length-prefixed context, fed to
+// Expand as info. Ethereum's and libp2p's post-handshake key schedules
+// build on exactly this shape rather than raw HKDF-Expand.
+func ExpandLabel(secret []byte, label string, context []byte, length int) ([]byte, error) {
+	if length > 0xffff {
+		return nil, errors.New("hkdf: length must fit in a uint16 for ExpandLabel")
+	}
+	fullLabel := "constantine " + label
+	if len(fullLabel) > 255 || len(context) > 255 {
+		return nil, errors.New("hkdf: label or context too long for ExpandLabel")
+	}
+
+	info := make([]byte, 0, 2+1+len(fullLabel)+1+len(context))
+	info = binary.BigEndian.AppendUint16(info, uint16(length))
+	info = append(info, byte(len(fullLabel)))
+	info = append(info, fullLabel...)
+	info = append(info, byte(len(context)))
+	info = append(info, context...)
+
+	return Expand(secret, info, length)
+}
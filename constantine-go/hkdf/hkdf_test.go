@@ -0,0 +1,55 @@
+package hkdf
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func decodeHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+// Test Case 1 from RFC 5869 §A.1 (Basic test case with SHA-256).
+func TestExtractExpandRFC5869Case1(t *testing.T) {
+	ikm := decodeHex(t, "0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b")
+	salt := decodeHex(t, "000102030405060708090a0b0c")
+	info := decodeHex(t, "f0f1f2f3f4f5f6f7f8f9")
+
+	wantPRK := decodeHex(t, "077709362c2e32df0ddc3f0dc47bba6390b6c73bb50f9c3122ec844ad7c2b3e5")
+	wantOKM := decodeHex(t, "3cb25f25faacd57a90434f64d0362f2a2d2d0a90cf1a5a4c5db02d56ecc4c5bf34007208d5b887185865")
+
+	prk := Extract(salt, ikm)
+	require.Equal(t, wantPRK, prk)
+
+	okm, err := Expand(prk, info, 42)
+	require.NoError(t, err)
+	require.Equal(t, wantOKM, okm)
+}
+
+func TestExpandLengthBounds(t *testing.T) {
+	prk := Extract(nil, []byte("ikm"))
+
+	_, err := Expand(prk, nil, 0)
+	require.Error(t, err)
+
+	_, err = Expand(prk, nil, 255*hashLen+1)
+	require.Error(t, err)
+}
+
+func TestExpandLabelDeterministic(t *testing.T) {
+	secret := Extract(nil, []byte("some secret"))
+
+	a, err := ExpandLabel(secret, "derived key", []byte("ctx"), 32)
+	require.NoError(t, err)
+	b, err := ExpandLabel(secret, "derived key", []byte("ctx"), 32)
+	require.NoError(t, err)
+	require.Equal(t, a, b)
+
+	c, err := ExpandLabel(secret, "different label", []byte("ctx"), 32)
+	require.NoError(t, err)
+	require.NotEqual(t, a, c)
+}
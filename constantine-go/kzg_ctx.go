@@ -0,0 +1,96 @@
+/** Constantine
+ *  Copyright (c) 2018-2019    Status Research & Development GmbH
+ *  Copyright (c) 2020-Present Mamy André-Ratsimbazafy
+ *  Licensed and distributed under either of
+ *    * MIT license (license terms in the root directory or at http://opensource.org/licenses/MIT).
+ *    * Apache v2 license (license terms in the root directory or at http://www.apache.org/licenses/LICENSE-2.0).
+ *  at your option. This file may not be copied, modified, or distributed except according to those terms.
+ */
+
+package constantine
+
+import "context"
+
+// Context-cancellable parallel KZG operations
+// -----------------------------------------------------
+//
+// The *Parallel methods on EthKzgContext dispatch a single blocking call
+// into the Nim threadpool and have no way to observe or cancel the work
+// mid-flight. These wrappers let a caller bound verification latency: the
+// call returns as soon as ctx is cancelled, with ctx.Err() as the error.
+//
+// The blocking call itself still has to run on a goroutine locked to its
+// OS thread for the Nim threadpool's thread-local state (see
+// threadpool_scope.go), so the goroutine below enters its own
+// ThreadpoolScope rather than calling the *Parallel method directly.
+//
+// NOTE: the underlying threadpool call cannot currently be interrupted
+// once dispatched — cancellation here means "stop waiting for it", not
+// "stop the in-flight MSM partitions". The goroutine running the native
+// call is left to finish (and its OS thread unlocked) once it completes;
+// its result is discarded if ctx was already cancelled. True mid-flight
+// cancellation needs a polling entrypoint on the C side; until then,
+// ProgressFunc is only invoked at the start and end of the call.
+
+// ProgressFunc is invoked with the number of items completed and the total
+// item count: blobs for VerifyBlobKzgProofBatchParallelCtx, cells for
+// ComputeCellsAndKzgProofsParallelCtx. done is only ever 0 or total.
+type ProgressFunc func(done, total int)
+
+func (ctx EthKzgContext) VerifyBlobKzgProofBatchParallelCtx(c context.Context, blobs []EthBlob, commitments []EthKzgCommitment, proofs []EthKzgProof, secureRandomBytes [32]byte, onProgress ProgressFunc) (bool, error) {
+	total := len(blobs)
+	if onProgress != nil {
+		onProgress(0, total)
+	}
+
+	type result struct {
+		valid bool
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		s := ctx.threadpool.Enter()
+		defer s.Close()
+		valid, err := s.VerifyBlobKzgProofBatch(ctx, blobs, commitments, proofs, secureRandomBytes)
+		done <- result{valid, err}
+	}()
+
+	select {
+	case <-c.Done():
+		return false, c.Err()
+	case r := <-done:
+		if onProgress != nil {
+			onProgress(total, total)
+		}
+		return r.valid, r.err
+	}
+}
+
+func (ctx EthKzgContext) ComputeCellsAndKzgProofsParallelCtx(c context.Context, blob EthBlob, onProgress ProgressFunc) (cells [CellsPerExtBlob]EthKzgCell, proofs [CellsPerExtBlob]EthKzgProof, err error) {
+	if onProgress != nil {
+		onProgress(0, CellsPerExtBlob)
+	}
+
+	type result struct {
+		cells  [CellsPerExtBlob]EthKzgCell
+		proofs [CellsPerExtBlob]EthKzgProof
+		err    error
+	}
+	done := make(chan result, 1)
+	go func() {
+		s := ctx.threadpool.Enter()
+		defer s.Close()
+		cells, proofs, err := s.ComputeCellsAndKzgProofs(ctx, blob)
+		done <- result{cells, proofs, err}
+	}()
+
+	select {
+	case <-c.Done():
+		return cells, proofs, c.Err()
+	case r := <-done:
+		if onProgress != nil {
+			onProgress(CellsPerExtBlob, CellsPerExtBlob)
+		}
+		return r.cells, r.proofs, r.err
+	}
+}
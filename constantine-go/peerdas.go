@@ -0,0 +1,187 @@
+/** Constantine
+ *  Copyright (c) 2018-2019    Status Research & Development GmbH
+ *  Copyright (c) 2020-Present Mamy André-Ratsimbazafy
+ *  Licensed and distributed under either of
+ *    * MIT license (license terms in the root directory or at http://opensource.org/licenses/MIT).
+ *    * Apache v2 license (license terms in the root directory or at http://www.apache.org/licenses/LICENSE-2.0).
+ *  at your option. This file may not be copied, modified, or distributed except according to those terms.
+ */
+
+package constantine
+
+/*
+#cgo CFLAGS: -I"${SRCDIR}/../include"
+#cgo !windows LDFLAGS: "${SRCDIR}/../lib/libconstantine.a"
+#cgo windows LDFLAGS: -L"${SRCDIR}/../lib" -Wl,-Bstatic -lconstantine -Wl,-Bdynamic
+
+#include <stdlib.h>
+#include <constantine.h>
+
+*/
+import "C"
+import (
+	"errors"
+	"unsafe"
+)
+
+// Ethereum EIP-7594 PeerDAS KZG API
+// -----------------------------------------------------
+//
+// Data-availability sampling splits each blob into CellsPerExtBlob cells,
+// each with its own KZG proof, so that a node can reconstruct a blob from
+// any half of its cells. This mirrors the cell API the c-kzg-4844 Go
+// bindings expose for Fulu/PeerDAS, so CL clients moving beyond EIP-4844
+// don't need a second KZG dependency just for cell proofs.
+
+const CellsPerExtBlob = 128
+
+type (
+	EthKzgCell      [2048]byte
+	EthKzgCellIndex = uint64
+)
+
+func (ctx EthKzgContext) ComputeCellsAndKzgProofs(blob EthBlob) (cells [CellsPerExtBlob]EthKzgCell, proofs [CellsPerExtBlob]EthKzgProof, err error) {
+	status := C.ctt_eth_kzg_compute_cells_and_kzg_proofs(
+		ctx.cCtx,
+		(*C.ctt_eth_kzg_cell)(unsafe.Pointer(&cells[0])),
+		(*C.ctt_eth_kzg_proof)(unsafe.Pointer(&proofs[0])),
+		(*C.ctt_eth_kzg_blob)(unsafe.Pointer(&blob)),
+	)
+	if status != C.cttEthKzg_Success {
+		err = errors.New(
+			C.GoString(C.ctt_eth_kzg_status_to_string(status)),
+		)
+	}
+	return cells, proofs, err
+}
+
+func (ctx EthKzgContext) RecoverCellsAndKzgProofs(cellIndices []EthKzgCellIndex, cells []EthKzgCell) (recovered [CellsPerExtBlob]EthKzgCell, proofs [CellsPerExtBlob]EthKzgProof, err error) {
+	if len(cellIndices) != len(cells) {
+		return recovered, proofs, errors.New("RecoverCellsAndKzgProofs: Lengths of cellIndices and cells do not match.")
+	}
+	if len(cells) == 0 {
+		return recovered, proofs, errors.New("RecoverCellsAndKzgProofs: No cells given.")
+	}
+	status := C.ctt_eth_kzg_recover_cells_and_kzg_proofs(
+		ctx.cCtx,
+		(*C.ctt_eth_kzg_cell)(unsafe.Pointer(&recovered[0])),
+		(*C.ctt_eth_kzg_proof)(unsafe.Pointer(&proofs[0])),
+		(*C.uint64_t)(unsafe.Pointer(&cellIndices[0])),
+		(*C.ctt_eth_kzg_cell)(unsafe.Pointer(&cells[0])),
+		(C.size_t)(len(cells)),
+	)
+	if status != C.cttEthKzg_Success {
+		err = errors.New(
+			C.GoString(C.ctt_eth_kzg_status_to_string(status)),
+		)
+	}
+	return recovered, proofs, err
+}
+
+func (ctx EthKzgContext) VerifyCellKzgProofBatch(commitments []EthKzgCommitment, cellIndices []EthKzgCellIndex, cells []EthKzgCell, proofs []EthKzgProof) (bool, error) {
+	if len(commitments) != len(cellIndices) || len(commitments) != len(cells) || len(commitments) != len(proofs) {
+		return false, errors.New("VerifyCellKzgProofBatch: Lengths of inputs do not match.")
+	}
+	if len(commitments) == 0 {
+		return false, errors.New("VerifyCellKzgProofBatch: No cells given.")
+	}
+
+	status := C.ctt_eth_kzg_verify_cell_kzg_proof_batch(
+		ctx.cCtx,
+		(*C.ctt_eth_kzg_commitment)(unsafe.Pointer(&commitments[0])),
+		(*C.uint64_t)(unsafe.Pointer(&cellIndices[0])),
+		(*C.ctt_eth_kzg_cell)(unsafe.Pointer(&cells[0])),
+		(*C.ctt_eth_kzg_proof)(unsafe.Pointer(&proofs[0])),
+		(C.size_t)(len(commitments)),
+	)
+	if status != C.cttEthKzg_Success {
+		if status == C.cttEthKzg_VerificationFailure {
+			return false, nil
+		}
+
+		err := errors.New(
+			C.GoString(C.ctt_eth_kzg_status_to_string(status)),
+		)
+		return false, err
+	}
+	return true, nil
+}
+
+// Ethereum EIP-7594 PeerDAS KZG API - Parallel
+// -----------------------------------------------------
+
+func (ctx EthKzgContext) ComputeCellsAndKzgProofsParallel(blob EthBlob) (cells [CellsPerExtBlob]EthKzgCell, proofs [CellsPerExtBlob]EthKzgProof, err error) {
+	if ctx.threadpool.ctx == nil {
+		return cells, proofs, errors.New("ComputeCellsAndKzgProofsParallel: The threadpool is not configured.")
+	}
+	status := C.ctt_eth_kzg_compute_cells_and_kzg_proofs_parallel(
+		ctx.threadpool.ctx, ctx.cCtx,
+		(*C.ctt_eth_kzg_cell)(unsafe.Pointer(&cells[0])),
+		(*C.ctt_eth_kzg_proof)(unsafe.Pointer(&proofs[0])),
+		(*C.ctt_eth_kzg_blob)(unsafe.Pointer(&blob)),
+	)
+	if status != C.cttEthKzg_Success {
+		err = errors.New(
+			C.GoString(C.ctt_eth_kzg_status_to_string(status)),
+		)
+	}
+	return cells, proofs, err
+}
+
+func (ctx EthKzgContext) RecoverCellsAndKzgProofsParallel(cellIndices []EthKzgCellIndex, cells []EthKzgCell) (recovered [CellsPerExtBlob]EthKzgCell, proofs [CellsPerExtBlob]EthKzgProof, err error) {
+	if len(cellIndices) != len(cells) {
+		return recovered, proofs, errors.New("RecoverCellsAndKzgProofsParallel: Lengths of cellIndices and cells do not match.")
+	}
+	if len(cells) == 0 {
+		return recovered, proofs, errors.New("RecoverCellsAndKzgProofsParallel: No cells given.")
+	}
+	if ctx.threadpool.ctx == nil {
+		return recovered, proofs, errors.New("RecoverCellsAndKzgProofsParallel: The threadpool is not configured.")
+	}
+	status := C.ctt_eth_kzg_recover_cells_and_kzg_proofs_parallel(
+		ctx.threadpool.ctx, ctx.cCtx,
+		(*C.ctt_eth_kzg_cell)(unsafe.Pointer(&recovered[0])),
+		(*C.ctt_eth_kzg_proof)(unsafe.Pointer(&proofs[0])),
+		(*C.uint64_t)(unsafe.Pointer(&cellIndices[0])),
+		(*C.ctt_eth_kzg_cell)(unsafe.Pointer(&cells[0])),
+		(C.size_t)(len(cells)),
+	)
+	if status != C.cttEthKzg_Success {
+		err = errors.New(
+			C.GoString(C.ctt_eth_kzg_status_to_string(status)),
+		)
+	}
+	return recovered, proofs, err
+}
+
+func (ctx EthKzgContext) VerifyCellKzgProofBatchParallel(commitments []EthKzgCommitment, cellIndices []EthKzgCellIndex, cells []EthKzgCell, proofs []EthKzgProof) (bool, error) {
+	if len(commitments) != len(cellIndices) || len(commitments) != len(cells) || len(commitments) != len(proofs) {
+		return false, errors.New("VerifyCellKzgProofBatchParallel: Lengths of inputs do not match.")
+	}
+	if len(commitments) == 0 {
+		return false, errors.New("VerifyCellKzgProofBatchParallel: No cells given.")
+	}
+	if ctx.threadpool.ctx == nil {
+		return false, errors.New("VerifyCellKzgProofBatchParallel: The threadpool is not configured.")
+	}
+
+	status := C.ctt_eth_kzg_verify_cell_kzg_proof_batch_parallel(
+		ctx.threadpool.ctx, ctx.cCtx,
+		(*C.ctt_eth_kzg_commitment)(unsafe.Pointer(&commitments[0])),
+		(*C.uint64_t)(unsafe.Pointer(&cellIndices[0])),
+		(*C.ctt_eth_kzg_cell)(unsafe.Pointer(&cells[0])),
+		(*C.ctt_eth_kzg_proof)(unsafe.Pointer(&proofs[0])),
+		(C.size_t)(len(commitments)),
+	)
+	if status != C.cttEthKzg_Success {
+		if status == C.cttEthKzg_VerificationFailure {
+			return false, nil
+		}
+
+		err := errors.New(
+			C.GoString(C.ctt_eth_kzg_status_to_string(status)),
+		)
+		return false, err
+	}
+	return true, nil
+}
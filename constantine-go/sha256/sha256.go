@@ -1,3 +1,5 @@
+//go:build cgo
+
 // sha256/sha256
 package sha256
 
@@ -15,10 +17,12 @@ package sha256
 */
 import "C"
 import (
+	"errors"
+	"hash"
 	"unsafe"
 )
 
-func getAddr[T any](arg []T) (unsafe.Pointer) {
+func getAddr[T any](arg []T) unsafe.Pointer {
 	// Makes sure to not access a non existant 0 element if the slice is empty
 	if len(arg) > 0 {
 		return unsafe.Pointer(&arg[0])
@@ -30,7 +34,10 @@ func getAddr[T any](arg []T) (unsafe.Pointer) {
 // Constantine's SHA256 API
 type Sha256Context C.ctt_sha256_context
 
-func New() (ctx Sha256Context) {
+// NewContext returns a zero-value Sha256Context; callers must call Init
+// before Update/Finish. Most callers should prefer New, which returns a
+// ready-to-use hash.Hash instead.
+func NewContext() (ctx Sha256Context) {
 	return ctx
 }
 
@@ -40,14 +47,21 @@ func (ctx *Sha256Context) Init() {
 
 func (ctx *Sha256Context) Update(data []byte) {
 	C.ctt_sha256_update((*C.ctt_sha256_context)(ctx),
-		(*C.byte)(unsafe.Pointer(&data[0])),
+		(*C.byte)(getAddr(data)),
 		(C.size_t)(len(data)),
 	)
 }
 
-func (ctx *Sha256Context) Finish(data [32]byte) {
+// Write implements io.Writer on top of Update, so a Sha256Context can be
+// streamed into directly with io.Copy and similar helpers.
+func (ctx *Sha256Context) Write(data []byte) (int, error) {
+	ctx.Update(data)
+	return len(data), nil
+}
+
+func (ctx *Sha256Context) Finish(digest *[32]byte) {
 	C.ctt_sha256_finish((*C.ctt_sha256_context)(ctx),
-		(*C.byte)(unsafe.Pointer(&data[0])),
+		(*C.byte)(unsafe.Pointer(digest)),
 	)
 }
 
@@ -55,11 +69,106 @@ func (ctx *Sha256Context) Clear() {
 	C.ctt_sha256_clear((*C.ctt_sha256_context)(ctx))
 }
 
+// Sum appends the digest to b without modifying ctx, so the caller may
+// keep writing afterward. Together with Write, Reset, Size and
+// BlockSize below, this lets a *Sha256Context be used as a hash.Hash
+// directly, without going through New's wrapper.
+func (ctx *Sha256Context) Sum(b []byte) []byte {
+	snapshot := *ctx
+	var digest [32]byte
+	snapshot.Finish(&digest)
+	return append(b, digest[:]...)
+}
+
+func (ctx *Sha256Context) Reset() {
+	ctx.Init()
+}
+
+func (ctx *Sha256Context) Size() int { return Size }
+
+func (ctx *Sha256Context) BlockSize() int { return BlockSize }
+
 func Hash(message []byte, clearMemory bool) (digest [32]byte) {
 	C.ctt_sha256_hash((*C.byte)(unsafe.Pointer(&digest)),
-		(*C.byte)(unsafe.Pointer(&message[0])),
+		(*C.byte)(getAddr(message)),
 		(C.size_t)(len(message)),
 		(C.ctt_bool)(clearMemory),
 	)
 	return digest
 }
+
+// hash.Hash adapter
+// -----------------------------------------------------
+//
+// *Sha256Context already satisfies hash.Hash on its own (Write, Sum,
+// Reset, Size, BlockSize above). hashState exists for New, which boxes
+// one behind the hash.Hash interface for stdlib-shaped code (HMAC, TLS
+// transcripts, Merkle-tree libraries) expecting what crypto/sha256.New()
+// returns, and additionally offers Marshal/UnmarshalBinary checkpointing.
+
+const (
+	// Size is the length in bytes of a SHA256 checksum.
+	Size = 32
+	// BlockSize is the block size, in bytes, of the SHA256 hash function.
+	BlockSize = 64
+)
+
+type hashState struct {
+	ctx Sha256Context
+}
+
+// New returns a new hash.Hash computing the SHA256 checksum, backed by
+// Constantine's implementation.
+func New() hash.Hash {
+	h := &hashState{}
+	h.ctx.Init()
+	return h
+}
+
+func (h *hashState) Write(p []byte) (int, error) {
+	h.ctx.Update(p)
+	return len(p), nil
+}
+
+// Sum appends the current hash to b without modifying the underlying
+// state, so the caller may keep writing afterward.
+func (h *hashState) Sum(b []byte) []byte {
+	snapshot := h.ctx
+	var digest [32]byte
+	snapshot.Finish(&digest)
+	return append(b, digest[:]...)
+}
+
+func (h *hashState) Reset() {
+	h.ctx.Init()
+}
+
+func (h *hashState) Size() int { return Size }
+
+func (h *hashState) BlockSize() int { return BlockSize }
+
+const marshaledStateMagic = "ctt sha256 v1"
+
+// MarshalBinary serializes the hasher's internal state, mirroring the
+// ability crypto/sha256's Hash offers to checkpoint a long-running hash.
+func (h *hashState) MarshalBinary() ([]byte, error) {
+	raw := (*[unsafe.Sizeof(h.ctx)]byte)(unsafe.Pointer(&h.ctx))[:]
+	out := make([]byte, 0, len(marshaledStateMagic)+len(raw))
+	out = append(out, marshaledStateMagic...)
+	out = append(out, raw...)
+	return out, nil
+}
+
+// UnmarshalBinary restores state produced by MarshalBinary.
+func (h *hashState) UnmarshalBinary(data []byte) error {
+	want := len(marshaledStateMagic) + int(unsafe.Sizeof(h.ctx))
+	if len(data) != want {
+		return errors.New("sha256: invalid hash state size")
+	}
+	if string(data[:len(marshaledStateMagic)]) != marshaledStateMagic {
+		return errors.New("sha256: invalid hash state identifier")
+	}
+	dst := (*[unsafe.Sizeof(h.ctx)]byte)(unsafe.Pointer(&h.ctx))[:]
+	copy(dst, data[len(marshaledStateMagic):])
+	return nil
+}
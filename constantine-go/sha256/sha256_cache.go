@@ -0,0 +1,105 @@
+//go:build cgo
+
+// sha256/sha256_cache
+package sha256
+
+/*
+#cgo CFLAGS: -I"${SRCDIR}/../../include"
+#cgo !windows LDFLAGS: "${SRCDIR}/../../lib/libconstantine.a"
+#cgo windows LDFLAGS: -L"${SRCDIR}/../../lib" -Wl,-Bstatic -lconstantine -Wl,-Bdynamic
+
+#include <stdlib.h>
+#include <constantine.h>
+
+*/
+import "C"
+import (
+	"runtime"
+	"sync"
+	"unsafe"
+)
+
+// Pooled native contexts
+// -----------------------------------------------------
+//
+// New/Hash allocate a fresh Sha256Context on the Go heap per call, which a
+// tight hashing loop (e.g. hashing every leaf of a Merkle tree) pays for
+// on every iteration. Mirroring crypto/internal/boring's Cache, HashInto
+// and Hasher instead borrow a C-malloc'd context from a sync.Pool, so
+// repeated hashing allocates only the returned digest. A GC finalizer
+// frees the native memory if a pooled context is ever dropped instead of
+// returned to the pool.
+
+type cachedCtx struct {
+	ptr *Sha256Context
+}
+
+func (c *cachedCtx) free() {
+	c.ptr.Clear()
+	C.free(unsafe.Pointer(c.ptr))
+}
+
+var ctxPool = sync.Pool{
+	New: func() any {
+		ptr := (*Sha256Context)(C.malloc(C.size_t(unsafe.Sizeof(Sha256Context{}))))
+		c := &cachedCtx{ptr: ptr}
+		runtime.SetFinalizer(c, (*cachedCtx).free)
+		return c
+	},
+}
+
+// HashInto writes the SHA256 digest of message into dst, reusing a pooled
+// native context instead of allocating a fresh Sha256Context per call.
+func HashInto(dst *[32]byte, message []byte) {
+	c := ctxPool.Get().(*cachedCtx)
+	defer ctxPool.Put(c)
+
+	c.ptr.Init()
+	c.ptr.Update(message)
+	c.ptr.Finish(dst)
+}
+
+// Hasher is a hash.Hash backed by a pooled native context. Unlike the
+// hash.Hash New returns, a Hasher holds onto malloc'd memory until Close
+// returns it to the pool, so it must be Closed once no longer needed.
+type Hasher struct {
+	c *cachedCtx
+}
+
+// NewHasher checks out a pooled native context and initializes it.
+func NewHasher() *Hasher {
+	c := ctxPool.Get().(*cachedCtx)
+	c.ptr.Init()
+	return &Hasher{c: c}
+}
+
+func (h *Hasher) Write(p []byte) (int, error) {
+	h.c.ptr.Update(p)
+	return len(p), nil
+}
+
+// Sum appends the digest to b without modifying the Hasher's state.
+func (h *Hasher) Sum(b []byte) []byte {
+	snapshot := *h.c.ptr
+	var digest [32]byte
+	snapshot.Finish(&digest)
+	return append(b, digest[:]...)
+}
+
+func (h *Hasher) Reset() {
+	h.c.ptr.Init()
+}
+
+func (h *Hasher) Size() int { return Size }
+
+func (h *Hasher) BlockSize() int { return BlockSize }
+
+// Close returns the Hasher's native context to the pool. The Hasher must
+// not be used afterward. Close is safe to call more than once.
+func (h *Hasher) Close() {
+	if h.c == nil {
+		return
+	}
+	ctxPool.Put(h.c)
+	h.c = nil
+}
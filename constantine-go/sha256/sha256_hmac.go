@@ -0,0 +1,100 @@
+//go:build cgo
+
+// sha256/sha256_hmac
+package sha256
+
+import "crypto/subtle"
+
+// HMAC-SHA256
+// -----------------------------------------------------
+//
+// RFC 2104 HMAC, built on two Sha256Context states for the inner/outer
+// hashes instead of routing through crypto/hmac + crypto/sha256 as callers
+// of this module otherwise would. This is the prerequisite primitive for
+// the EIP-2333 BLS key-derivation path, Ethereum's hash_to_field, and any
+// noise-protocol/JWT code that wants Constantine's clear-memory guarantees
+// end to end rather than just for the raw digest.
+
+// HMAC computes a keyed SHA256 MAC. The zero value is not usable; construct
+// one with NewHMAC.
+type HMAC struct {
+	inner     Sha256Context // streams Write data, seeded with key XOR ipad
+	innerInit Sha256Context // snapshot right after the ipad block, restored by Reset
+	outerInit Sha256Context // snapshot right after the opad block
+}
+
+// NewHMAC returns an HMAC-SHA256 keyed with key.
+func NewHMAC(key []byte) *HMAC {
+	h := &HMAC{}
+
+	var keyBlock [BlockSize]byte
+	if len(key) > BlockSize {
+		sum := Hash(key, false)
+		copy(keyBlock[:], sum[:])
+	} else {
+		copy(keyBlock[:], key)
+	}
+
+	var ipad, opad [BlockSize]byte
+	for i := 0; i < BlockSize; i++ {
+		ipad[i] = keyBlock[i] ^ 0x36
+		opad[i] = keyBlock[i] ^ 0x5c
+	}
+
+	h.inner.Init()
+	h.inner.Update(ipad[:])
+	h.innerInit = h.inner
+
+	h.outerInit.Init()
+	h.outerInit.Update(opad[:])
+
+	return h
+}
+
+func (h *HMAC) Write(p []byte) (int, error) {
+	h.inner.Update(p)
+	return len(p), nil
+}
+
+// Sum appends the current MAC to b without modifying h's state, so the
+// caller may keep Writing afterward. The key-derived snapshots it takes to
+// do so are cleared before returning.
+func (h *HMAC) Sum(b []byte) []byte {
+	innerSnapshot := h.inner
+	var innerDigest [32]byte
+	innerSnapshot.Finish(&innerDigest)
+	innerSnapshot.Clear()
+
+	outer := h.outerInit
+	outer.Update(innerDigest[:])
+	var digest [32]byte
+	outer.Finish(&digest)
+	outer.Clear()
+
+	return append(b, digest[:]...)
+}
+
+// Reset discards any data fed via Write and restarts from the key schedule,
+// clearing the discarded inner state so it doesn't linger in memory.
+func (h *HMAC) Reset() {
+	h.inner.Clear()
+	h.inner = h.innerInit
+}
+
+func (h *HMAC) Size() int { return Size }
+
+func (h *HMAC) BlockSize() int { return BlockSize }
+
+// Equal reports, in constant time, whether mac matches h's current MAC.
+func (h *HMAC) Equal(mac []byte) bool {
+	return subtle.ConstantTimeCompare(h.Sum(nil), mac) == 1
+}
+
+// Clear wipes every state this HMAC holds, including the key schedule. The
+// HMAC must not be used afterward; call this once the caller is done with
+// it, as opposed to Reset, which keeps it usable for the same key.
+func (h *HMAC) Clear() {
+	h.inner.Clear()
+	h.innerInit.Clear()
+	h.outerInit.Clear()
+}
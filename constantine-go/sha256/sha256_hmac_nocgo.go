@@ -0,0 +1,40 @@
+//go:build !cgo
+
+// sha256/sha256_hmac_nocgo
+package sha256
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"hash"
+)
+
+// HMAC mirrors the cgo build's HMAC-SHA256, backed by crypto/hmac instead
+// of a pair of Sha256Context states.
+type HMAC struct {
+	h hash.Hash
+}
+
+// NewHMAC returns an HMAC-SHA256 keyed with key.
+func NewHMAC(key []byte) *HMAC {
+	return &HMAC{h: hmac.New(sha256.New, key)}
+}
+
+func (h *HMAC) Write(p []byte) (int, error) { return h.h.Write(p) }
+
+func (h *HMAC) Sum(b []byte) []byte { return h.h.Sum(b) }
+
+func (h *HMAC) Reset() { h.h.Reset() }
+
+func (h *HMAC) Size() int { return h.h.Size() }
+
+func (h *HMAC) BlockSize() int { return h.h.BlockSize() }
+
+// Equal reports, in constant time, whether mac matches h's current MAC.
+func (h *HMAC) Equal(mac []byte) bool {
+	return hmac.Equal(h.Sum(nil), mac)
+}
+
+// Clear is a no-op in the pure-Go build: there is no native state to wipe,
+// and crypto/hmac doesn't expose a way to scrub its internal buffers.
+func (h *HMAC) Clear() {}
@@ -0,0 +1,55 @@
+package sha256
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Test case 1 from RFC 4231 §4.2.
+func TestHMACRFC4231Case1(t *testing.T) {
+	key, err := hex.DecodeString("0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b")
+	require.NoError(t, err)
+	data := []byte("Hi There")
+	want, err := hex.DecodeString("b0344c61d8db38535ca8afceaf0bf12b881dc200c9833da726e9376c2e32cff7")
+	require.NoError(t, err)
+
+	mac := NewHMAC(key)
+	mac.Write(data)
+	got := mac.Sum(nil)
+	require.Equal(t, want, got)
+}
+
+func TestHMACResetMatchesFreshKey(t *testing.T) {
+	key := []byte("a reset test key")
+	data := []byte("some message to authenticate")
+
+	fresh := NewHMAC(key)
+	fresh.Write(data)
+	want := fresh.Sum(nil)
+
+	reused := NewHMAC(key)
+	reused.Write([]byte("unrelated data first"))
+	_ = reused.Sum(nil)
+	reused.Reset()
+	reused.Write(data)
+	got := reused.Sum(nil)
+
+	require.Equal(t, want, got)
+}
+
+func TestHMACEqual(t *testing.T) {
+	key := []byte("equal-test-key")
+	data := []byte("message")
+
+	mac := NewHMAC(key)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	require.True(t, mac.Equal(sum))
+
+	tampered := append([]byte{}, sum...)
+	tampered[0] ^= 0xff
+	require.False(t, mac.Equal(tampered))
+}
@@ -0,0 +1,16 @@
+// sha256/sha256_io
+package sha256
+
+import "io"
+
+// Sum streams r through SHA256 via io.Copy, instead of requiring the whole
+// input to already be in memory the way Hash does — useful for hashing
+// large files or network bodies.
+func Sum(r io.Reader) (digest [32]byte, err error) {
+	h := New()
+	if _, err = io.Copy(h, r); err != nil {
+		return digest, err
+	}
+	copy(digest[:], h.Sum(nil))
+	return digest, nil
+}
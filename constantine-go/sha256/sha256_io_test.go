@@ -0,0 +1,71 @@
+package sha256
+
+import (
+	"bytes"
+	"hash"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var _ hash.Hash = (*Sha256Context)(nil)
+
+func TestSha256ContextSatisfiesHashHash(t *testing.T) {
+	msg := []byte("the quick brown fox jumps over the lazy dog")
+
+	ctx := NewContext()
+	ctx.Init()
+	_, err := ctx.Write(msg)
+	require.NoError(t, err)
+	want := Hash(msg, false)
+	require.Equal(t, want[:], ctx.Sum(nil))
+
+	// Sum must be non-destructive.
+	_, err = ctx.Write([]byte(" again"))
+	require.NoError(t, err)
+	want = Hash(append(msg, []byte(" again")...), false)
+	require.Equal(t, want[:], ctx.Sum(nil))
+
+	ctx.Reset()
+	_, err = ctx.Write(msg)
+	require.NoError(t, err)
+	want = Hash(msg, false)
+	require.Equal(t, want[:], ctx.Sum(nil))
+
+	require.Equal(t, Size, ctx.Size())
+	require.Equal(t, BlockSize, ctx.BlockSize())
+}
+
+func TestSumMatchesHash(t *testing.T) {
+	msg := []byte("the quick brown fox jumps over the lazy dog")
+	want := Hash(msg, false)
+
+	got, err := Sum(bytes.NewReader(msg))
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+}
+
+func TestSumEmptyReader(t *testing.T) {
+	want := Hash(nil, false)
+
+	got, err := Sum(bytes.NewReader(nil))
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+}
+
+func TestMultiHashMatchesHash(t *testing.T) {
+	msgs := make([][]byte, 16)
+	for i := range msgs {
+		msgs[i] = []byte{byte(i), byte(i + 1), byte(i + 2)}
+	}
+
+	got := MultiHash(msgs)
+	require.Len(t, got, len(msgs))
+	for i, msg := range msgs {
+		require.Equal(t, Hash(msg, false), got[i])
+	}
+}
+
+func TestMultiHashEmpty(t *testing.T) {
+	require.Empty(t, MultiHash(nil))
+}
@@ -0,0 +1,52 @@
+//go:build cgo
+
+// sha256/sha256_multi
+package sha256
+
+/*
+#cgo CFLAGS: -I"${SRCDIR}/../../include"
+#cgo !windows LDFLAGS: "${SRCDIR}/../../lib/libconstantine.a"
+#cgo windows LDFLAGS: -L"${SRCDIR}/../../lib" -Wl,-Bstatic -lconstantine -Wl,-Bdynamic
+
+#include <stdlib.h>
+#include <constantine.h>
+
+*/
+import "C"
+
+// flattenMessages packs messages into one contiguous buffer plus a
+// parallel length-per-message array, since C has no notion of a jagged
+// [][]byte.
+func flattenMessages(messages [][]byte) (flat []byte, lengths []C.size_t) {
+	total := 0
+	for _, m := range messages {
+		total += len(m)
+	}
+	flat = make([]byte, 0, total)
+	lengths = make([]C.size_t, len(messages))
+	for i, m := range messages {
+		flat = append(flat, m...)
+		lengths[i] = C.size_t(len(m))
+	}
+	return flat, lengths
+}
+
+// MultiHash hashes every message in msgs in a single call, dispatching to
+// Constantine's SIMD-batched ctt_sha256_hash_batch entrypoint instead of
+// looping over Hash one message at a time — the shape Ethereum's 4096-leaf
+// SSZ Merkleization and similar workloads actually need.
+func MultiHash(msgs [][]byte) [][32]byte {
+	digests := make([][32]byte, len(msgs))
+	if len(msgs) == 0 {
+		return digests
+	}
+
+	flat, lengths := flattenMessages(msgs)
+	C.ctt_sha256_hash_batch(
+		(*C.byte)(getAddr(digests)),
+		(*C.byte)(getAddr(flat)),
+		(*C.size_t)(getAddr(lengths)),
+		(C.size_t)(len(msgs)),
+	)
+	return digests
+}
@@ -0,0 +1,43 @@
+//go:build !cgo
+
+// sha256/sha256_multi_nocgo
+package sha256
+
+import (
+	"runtime"
+	"sync"
+)
+
+// MultiHash hashes every message in msgs, sharding the work across a
+// worker pool bounded by GOMAXPROCS: the pure-Go build has no SIMD-batched
+// entrypoint to dispatch to, so goroutines are the next best thing.
+func MultiHash(msgs [][]byte) [][32]byte {
+	digests := make([][32]byte, len(msgs))
+	if len(msgs) == 0 {
+		return digests
+	}
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(msgs) {
+		workers = len(msgs)
+	}
+
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				digests[i] = Hash(msgs[i], false)
+			}
+		}()
+	}
+	for i := range msgs {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+
+	return digests
+}
@@ -0,0 +1,91 @@
+//go:build !cgo
+
+// sha256/sha256_nocgo
+package sha256
+
+// Pure-Go fallback
+// -----------------------------------------------------
+//
+// The sibling sha256.go binds Constantine's C implementation, which is
+// unavailable under CGO_ENABLED=0, cross-compilation to a platform without
+// a C toolchain, TinyGo, and similar cgo-less builds. This file mirrors its
+// API on top of the standard library's crypto/sha256 instead, so callers
+// can depend on this package without caring which build produced it.
+
+import (
+	"crypto/sha256"
+	"hash"
+)
+
+const (
+	// Size is the length in bytes of a SHA256 checksum.
+	Size = sha256.Size
+	// BlockSize is the block size, in bytes, of the SHA256 hash function.
+	BlockSize = sha256.BlockSize
+)
+
+// Sha256Context mirrors the cgo build's streaming context, backed by
+// crypto/sha256's hash.Hash instead of a C struct.
+type Sha256Context struct {
+	h hash.Hash
+}
+
+// NewContext returns an initialized Sha256Context; callers may also use New,
+// which returns a hash.Hash instead.
+func NewContext() (ctx Sha256Context) {
+	ctx.h = sha256.New()
+	return ctx
+}
+
+func (ctx *Sha256Context) Init() {
+	ctx.h = sha256.New()
+}
+
+func (ctx *Sha256Context) Update(data []byte) {
+	ctx.h.Write(data)
+}
+
+// Write implements io.Writer on top of Update, so a Sha256Context can be
+// streamed into directly with io.Copy and similar helpers.
+func (ctx *Sha256Context) Write(data []byte) (int, error) {
+	return ctx.h.Write(data)
+}
+
+func (ctx *Sha256Context) Finish(digest *[32]byte) {
+	sum := ctx.h.Sum(digest[:0])
+	copy(digest[:], sum)
+}
+
+// Clear drops the context's state. There is no sensitive native buffer to
+// wipe in the pure-Go build; this only releases ctx.h for GC.
+func (ctx *Sha256Context) Clear() {
+	ctx.h = nil
+}
+
+// Sum appends the digest to b without modifying ctx, so the caller may
+// keep writing afterward. Together with Write, Reset, Size and
+// BlockSize below, this lets a *Sha256Context be used as a hash.Hash
+// directly, without going through New's wrapper.
+func (ctx *Sha256Context) Sum(b []byte) []byte {
+	return ctx.h.Sum(b)
+}
+
+func (ctx *Sha256Context) Reset() {
+	ctx.h.Reset()
+}
+
+func (ctx *Sha256Context) Size() int { return Size }
+
+func (ctx *Sha256Context) BlockSize() int { return BlockSize }
+
+// Hash returns the SHA256 digest of message. clearMemory is accepted for API
+// parity with the cgo build, where it requests that internal buffers be
+// wiped after hashing; the pure-Go implementation has no such buffer to wipe.
+func Hash(message []byte, clearMemory bool) (digest [32]byte) {
+	return sha256.Sum256(message)
+}
+
+// New returns a new hash.Hash computing the SHA256 checksum.
+func New() hash.Hash {
+	return sha256.New()
+}
@@ -0,0 +1,82 @@
+//go:build cgo
+
+package sha256
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashIntoMatchesHash(t *testing.T) {
+	msg := []byte("the quick brown fox jumps over the lazy dog")
+	want := Hash(msg, false)
+
+	var got [32]byte
+	HashInto(&got, msg)
+	require.Equal(t, want, got)
+}
+
+func TestHashIntoEmptyMessage(t *testing.T) {
+	want := Hash(nil, false)
+
+	var got [32]byte
+	HashInto(&got, nil)
+	require.Equal(t, want, got)
+}
+
+func TestHasherRoundTrip(t *testing.T) {
+	msg := []byte("the quick brown fox jumps over the lazy dog")
+
+	h := NewHasher()
+	defer h.Close()
+
+	_, err := h.Write(msg)
+	require.NoError(t, err)
+	want := Hash(msg, false)
+	require.Equal(t, want[:], h.Sum(nil))
+
+	// Sum must be non-destructive.
+	_, err = h.Write([]byte(" again"))
+	require.NoError(t, err)
+	want = Hash(append(msg, []byte(" again")...), false)
+	require.Equal(t, want[:], h.Sum(nil))
+
+	h.Reset()
+	_, err = h.Write(msg)
+	require.NoError(t, err)
+	want = Hash(msg, false)
+	require.Equal(t, want[:], h.Sum(nil))
+}
+
+func BenchmarkHash(b *testing.B) {
+	msg := make([]byte, 1024)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = Hash(msg, false)
+	}
+}
+
+func BenchmarkHashInto(b *testing.B) {
+	msg := make([]byte, 1024)
+	var digest [32]byte
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		HashInto(&digest, msg)
+	}
+}
+
+func BenchmarkHasher(b *testing.B) {
+	msg := make([]byte, 1024)
+	h := NewHasher()
+	defer h.Close()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h.Reset()
+		h.Write(msg)
+		h.Sum(nil)
+	}
+}
@@ -0,0 +1,95 @@
+/** Constantine
+ *  Copyright (c) 2018-2019    Status Research & Development GmbH
+ *  Copyright (c) 2020-Present Mamy André-Ratsimbazafy
+ *  Licensed and distributed under either of
+ *    * MIT license (license terms in the root directory or at http://opensource.org/licenses/MIT).
+ *    * Apache v2 license (license terms in the root directory or at http://www.apache.org/licenses/LICENSE-2.0).
+ *  at your option. This file may not be copied, modified, or distributed except according to those terms.
+ */
+
+package constantine
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// Threadpool affinity
+// -----------------------------------------------------
+//
+// The Nim threadpool keeps per-OS-thread state, so every C call made
+// against a given Threadpool must come from the same OS thread for the
+// lifetime of that thread's work, and callers must never hand off to
+// `t.Run` subtests (which Go may reschedule onto a different goroutine,
+// and therefore a different OS thread). ThreadpoolScope wraps that rule
+// in a type: its methods run the *Parallel KZG operations through a scope
+// obtained from Threadpool.Enter or Threadpool.Run, both of which pin the
+// calling goroutine to its OS thread for the scope's lifetime. The scope
+// does not cover the BLS *Parallel functions, and the *Parallel methods of
+// EthKzgContext (after SetThreadpool) and BatchVerifySoAParallel/
+// AoSParallel stay callable directly, with thread pinning left to the
+// caller.
+
+// ThreadpoolScope grants access to the *Parallel methods of an
+// EthKzgContext while the current goroutine is locked to its OS thread.
+type ThreadpoolScope struct {
+	tp Threadpool
+}
+
+// Enter locks the calling goroutine to its current OS thread and returns a
+// scope through which parallel operations may be run. The caller must call
+// Close when done, typically via defer.
+func (tp Threadpool) Enter() *ThreadpoolScope {
+	runtime.LockOSThread()
+	return &ThreadpoolScope{tp: tp}
+}
+
+// Close releases the OS thread lock acquired by Enter. It is safe to call
+// Close only once; calling it more than once will over-unlock the thread.
+func (s *ThreadpoolScope) Close() {
+	runtime.UnlockOSThread()
+}
+
+// Run locks the calling goroutine to its OS thread, invokes fn with a
+// scope, unlocks on return, and converts a panic inside fn into an error
+// instead of crashing with a locked OS thread.
+func (tp Threadpool) Run(fn func(s *ThreadpoolScope) error) (err error) {
+	s := tp.Enter()
+	defer s.Close()
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic in threadpool scope: %v", r)
+		}
+	}()
+	return fn(s)
+}
+
+func (s *ThreadpoolScope) BlobToKzgCommitment(ctx EthKzgContext, blob EthBlob) (EthKzgCommitment, error) {
+	ctx.threadpool = s.tp
+	return ctx.BlobToKzgCommitmentParallel(blob)
+}
+
+func (s *ThreadpoolScope) ComputeKzgProof(ctx EthKzgContext, blob EthBlob, z EthKzgChallenge) (EthKzgProof, EthKzgEvalAtChallenge, error) {
+	ctx.threadpool = s.tp
+	return ctx.ComputeKzgProofParallel(blob, z)
+}
+
+func (s *ThreadpoolScope) ComputeBlobKzgProof(ctx EthKzgContext, blob EthBlob, commitment EthKzgCommitment) (EthKzgProof, error) {
+	ctx.threadpool = s.tp
+	return ctx.ComputeBlobKzgProofParallel(blob, commitment)
+}
+
+func (s *ThreadpoolScope) VerifyBlobKzgProof(ctx EthKzgContext, blob EthBlob, commitment EthKzgCommitment, proof EthKzgProof) (bool, error) {
+	ctx.threadpool = s.tp
+	return ctx.VerifyBlobKzgProofParallel(blob, commitment, proof)
+}
+
+func (s *ThreadpoolScope) VerifyBlobKzgProofBatch(ctx EthKzgContext, blobs []EthBlob, commitments []EthKzgCommitment, proofs []EthKzgProof, secureRandomBytes [32]byte) (bool, error) {
+	ctx.threadpool = s.tp
+	return ctx.VerifyBlobKzgProofBatchParallel(blobs, commitments, proofs, secureRandomBytes)
+}
+
+func (s *ThreadpoolScope) ComputeCellsAndKzgProofs(ctx EthKzgContext, blob EthBlob) (cells [CellsPerExtBlob]EthKzgCell, proofs [CellsPerExtBlob]EthKzgProof, err error) {
+	ctx.threadpool = s.tp
+	return ctx.ComputeCellsAndKzgProofsParallel(blob)
+}
@@ -0,0 +1,124 @@
+/** Constantine
+ *  Copyright (c) 2018-2019    Status Research & Development GmbH
+ *  Copyright (c) 2020-Present Mamy André-Ratsimbazafy
+ *  Licensed and distributed under either of
+ *    * MIT license (license terms in the root directory or at http://opensource.org/licenses/MIT).
+ *    * Apache v2 license (license terms in the root directory or at http://www.apache.org/licenses/LICENSE-2.0).
+ *  at your option. This file may not be copied, modified, or distributed except according to those terms.
+ */
+
+package constantine
+
+/*
+#cgo CFLAGS: -I"${SRCDIR}/../include"
+#cgo !windows LDFLAGS: "${SRCDIR}/../lib/libconstantine.a"
+#cgo windows LDFLAGS: -L"${SRCDIR}/../lib" -Wl,-Bstatic -lconstantine -Wl,-Bdynamic
+
+#include <stdlib.h>
+#include <constantine.h>
+
+*/
+import "C"
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"unsafe"
+)
+
+// Trusted setup loaders - from memory / embedded sources
+// -----------------------------------------------------
+//
+// EthKzgContextNew shells through a filesystem path, which is awkward for
+// binaries that embed the reference setup via `//go:embed` rather than
+// shipping it as a sibling file. These constructors parse the setup
+// in-process and hand the bytes directly to the C side.
+
+// EthKzgTrustedSetupFormat selects the on-disk/in-memory layout of a
+// trusted setup passed to the `FromBytes`/`FromReader` constructors.
+type EthKzgTrustedSetupFormat int
+
+const (
+	// TSFormatCKZG4844 is the binary layout used by the reference
+	// implementation (c-kzg-4844) and the one EthKzgContextNew loads.
+	TSFormatCKZG4844 EthKzgTrustedSetupFormat = iota
+	// TSFormatJSON is the human-readable layout used across the broader
+	// Ethereum ecosystem: a G1 Lagrange point list, a G1 monomial point
+	// list, and a G2 monomial point list.
+	TSFormatJSON
+)
+
+func (f EthKzgTrustedSetupFormat) toC() C.cttEthTSFormat {
+	switch f {
+	case TSFormatJSON:
+		return C.cttEthTSFormat_json
+	default:
+		return C.cttEthTSFormat_ckzg4844
+	}
+}
+
+// ethJSONTrustedSetup mirrors the JSON layout published alongside the
+// mainnet KZG ceremony: a hex-encoded G1 Lagrange basis, a hex-encoded G1
+// monomial basis, and a hex-encoded G2 monomial basis.
+type ethJSONTrustedSetup struct {
+	SetupG1Lagrange []string `json:"setup_G1_lagrange"`
+	SetupG1         []string `json:"setup_G1"`
+	SetupG2         []string `json:"setup_G2"`
+}
+
+// EthKzgContextNewFromBytes loads a trusted setup held entirely in memory,
+// e.g. embedded into the binary via `//go:embed`. precomputeBits trades
+// construction-time RAM and setup latency for faster MSMs afterwards; pass
+// 0 to use the library's default.
+func EthKzgContextNewFromBytes(setup []byte, format EthKzgTrustedSetupFormat, precomputeBits int) (ctx EthKzgContext, err error) {
+	if len(setup) == 0 {
+		return ctx, errors.New("EthKzgContextNewFromBytes: empty trusted setup buffer.")
+	}
+	status := C.ctt_eth_trusted_setup_load_from_buffer(
+		&ctx.cCtx,
+		(*C.byte)(unsafe.Pointer(&setup[0])),
+		(C.size_t)(len(setup)),
+		format.toC(),
+		(C.int)(precomputeBits),
+	)
+	if status != C.cttEthTS_Success {
+		err = errors.New(
+			C.GoString(C.ctt_eth_trusted_setup_status_to_string(status)),
+		)
+	}
+	ctx.threadpool.ctx = nil
+	return ctx, err
+}
+
+// EthKzgContextNewFromReader loads a TSFormatCKZG4844 trusted setup from an
+// arbitrary io.Reader (e.g. a file, an embedded asset, or an HTTP body),
+// sparing callers the trip through a filesystem path that EthKzgContextNew
+// requires.
+func EthKzgContextNewFromReader(r io.Reader, precomputeBits int) (ctx EthKzgContext, err error) {
+	setup, err := io.ReadAll(r)
+	if err != nil {
+		return ctx, err
+	}
+	return EthKzgContextNewFromBytes(setup, TSFormatCKZG4844, precomputeBits)
+}
+
+// EthKzgContextNewFromJSON loads the human-readable JSON trusted setup
+// layout used across the broader Ethereum ecosystem tooling.
+func EthKzgContextNewFromJSON(r io.Reader, precomputeBits int) (ctx EthKzgContext, err error) {
+	var parsed ethJSONTrustedSetup
+	if err = json.NewDecoder(r).Decode(&parsed); err != nil {
+		return ctx, err
+	}
+	if len(parsed.SetupG1Lagrange) == 0 || len(parsed.SetupG1) == 0 || len(parsed.SetupG2) == 0 {
+		return ctx, errors.New("EthKzgContextNewFromJSON: trusted setup JSON is missing one of setup_G1_lagrange/setup_G1/setup_G2.")
+	}
+
+	// Re-serialize the three validated point lists to JSON and load them
+	// through EthKzgContextNewFromBytes with TSFormatJSON, so the bytes and
+	// JSON constructors share a single C entrypoint.
+	raw, err := json.Marshal(parsed)
+	if err != nil {
+		return ctx, err
+	}
+	return EthKzgContextNewFromBytes(raw, TSFormatJSON, precomputeBits)
+}
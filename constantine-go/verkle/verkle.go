@@ -0,0 +1,244 @@
+/** Constantine
+ *  Copyright (c) 2018-2019    Status Research & Development GmbH
+ *  Copyright (c) 2020-Present Mamy André-Ratsimbazafy
+ *  Licensed and distributed under either of
+ *    * MIT license (license terms in the root directory or at http://opensource.org/licenses/MIT).
+ *    * Apache v2 license (license terms in the root directory or at http://www.apache.org/licenses/LICENSE-2.0).
+ *  at your option. This file may not be copied, modified, or distributed except according to those terms.
+ */
+
+// Package verkle wraps Constantine's Bandersnatch/IPA Verkle proof
+// primitives, the machinery behind the upcoming Ethereum "Verkle trees"
+// stateless-client precompiles.
+package verkle
+
+/*
+#cgo CFLAGS: -I"${SRCDIR}/../../include"
+#cgo !windows LDFLAGS: "${SRCDIR}/../../lib/libconstantine.a"
+#cgo windows LDFLAGS: -L"${SRCDIR}/../../lib" -Wl,-Bstatic -lconstantine -Wl,-Bdynamic
+
+#include <stdlib.h>
+#include <constantine.h>
+
+*/
+import "C"
+import (
+	"encoding/binary"
+	"errors"
+	"unsafe"
+)
+
+// IPADepth is the fixed depth of Constantine's IPA argument over the
+// Banderwagon/Bandersnatch curve used by the Verkle trie multiproof.
+const IPADepth = 8
+
+// IPAProof is the inner product argument attached to a VerkleProof.
+type IPAProof struct {
+	CL              [IPADepth][32]byte
+	CR              [IPADepth][32]byte
+	FinalEvaluation [32]byte
+}
+
+// VerkleProof mirrors the wire format used by the "electra" Ethereum
+// execution-spec Verkle proof: the stems not already covered by the
+// accessed keys, the depth/extension-presence byte per stem, the
+// commitments along the accessed paths, the aggregated evaluation point
+// D, and the IPA opening itself.
+type VerkleProof struct {
+	OtherStems            [][31]byte
+	DepthExtensionPresent []byte
+	CommitmentsByPath     [][32]byte
+	D                     [32]byte
+	IPAProof              IPAProof
+}
+
+var (
+	ErrTruncatedProof = errors.New("verkle: truncated proof bytes")
+	ErrEmptyProof     = errors.New("verkle: nil proof")
+)
+
+func putLengthPrefixed(dst []byte, n uint32) []byte {
+	var lenBytes [4]byte
+	binary.LittleEndian.PutUint32(lenBytes[:], n)
+	return append(dst, lenBytes[:]...)
+}
+
+// SerializeProof encodes a VerkleProof in the order: stems-count/stems,
+// depths-count/depths, commitments-count/commitments, D, CL[0..7],
+// CR[0..7], FinalEvaluation. Variable-length sections are prefixed with a
+// 4-byte little-endian element count.
+func SerializeProof(proof *VerkleProof) ([]byte, error) {
+	if proof == nil {
+		return nil, ErrEmptyProof
+	}
+
+	out := make([]byte, 0,
+		4+len(proof.OtherStems)*31+
+			4+len(proof.DepthExtensionPresent)+
+			4+len(proof.CommitmentsByPath)*32+
+			32+
+			2*IPADepth*32+32,
+	)
+
+	out = putLengthPrefixed(out, uint32(len(proof.OtherStems)))
+	for _, stem := range proof.OtherStems {
+		out = append(out, stem[:]...)
+	}
+
+	out = putLengthPrefixed(out, uint32(len(proof.DepthExtensionPresent)))
+	out = append(out, proof.DepthExtensionPresent...)
+
+	out = putLengthPrefixed(out, uint32(len(proof.CommitmentsByPath)))
+	for _, c := range proof.CommitmentsByPath {
+		out = append(out, c[:]...)
+	}
+
+	out = append(out, proof.D[:]...)
+
+	for _, cl := range proof.IPAProof.CL {
+		out = append(out, cl[:]...)
+	}
+	for _, cr := range proof.IPAProof.CR {
+		out = append(out, cr[:]...)
+	}
+	out = append(out, proof.IPAProof.FinalEvaluation[:]...)
+
+	return out, nil
+}
+
+func takeLengthPrefix(b []byte) (n uint32, rest []byte, err error) {
+	if len(b) < 4 {
+		return 0, nil, ErrTruncatedProof
+	}
+	return binary.LittleEndian.Uint32(b[:4]), b[4:], nil
+}
+
+// DeserializeProof decodes a VerkleProof encoded by SerializeProof.
+func DeserializeProof(b []byte) (*VerkleProof, error) {
+	proof := &VerkleProof{}
+
+	nStems, b, err := takeLengthPrefix(b)
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(b)) < uint64(nStems)*31 {
+		return nil, ErrTruncatedProof
+	}
+	proof.OtherStems = make([][31]byte, nStems)
+	for i := range proof.OtherStems {
+		copy(proof.OtherStems[i][:], b[:31])
+		b = b[31:]
+	}
+
+	nDepths, b, err := takeLengthPrefix(b)
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(b)) < uint64(nDepths) {
+		return nil, ErrTruncatedProof
+	}
+	proof.DepthExtensionPresent = make([]byte, nDepths)
+	copy(proof.DepthExtensionPresent, b[:nDepths])
+	b = b[nDepths:]
+
+	nCommitments, b, err := takeLengthPrefix(b)
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(b)) < uint64(nCommitments)*32 {
+		return nil, ErrTruncatedProof
+	}
+	proof.CommitmentsByPath = make([][32]byte, nCommitments)
+	for i := range proof.CommitmentsByPath {
+		copy(proof.CommitmentsByPath[i][:], b[:32])
+		b = b[32:]
+	}
+
+	if len(b) < 32 {
+		return nil, ErrTruncatedProof
+	}
+	copy(proof.D[:], b[:32])
+	b = b[32:]
+
+	if len(b) < 2*IPADepth*32+32 {
+		return nil, ErrTruncatedProof
+	}
+	for i := 0; i < IPADepth; i++ {
+		copy(proof.IPAProof.CL[i][:], b[:32])
+		b = b[32:]
+	}
+	for i := 0; i < IPADepth; i++ {
+		copy(proof.IPAProof.CR[i][:], b[:32])
+		b = b[32:]
+	}
+	copy(proof.IPAProof.FinalEvaluation[:], b[:32])
+
+	return proof, nil
+}
+
+// flattenFixed32 packs fixed-width 32-byte keys/values into one
+// contiguous buffer each, since the C side expects flat arrays rather
+// than Go's independently-allocated [][]byte. A nil entry is encoded as
+// 32 zero bytes, so callers can pass a nil value for the value of an
+// absence proof (absence itself is signaled by DepthExtensionPresent in
+// the proof, not by the value bytes).
+func flattenFixed32(items [][]byte) ([]byte, error) {
+	flat := make([]byte, 0, len(items)*32)
+	for _, item := range items {
+		if item == nil {
+			flat = append(flat, make([]byte, 32)...)
+			continue
+		}
+		if len(item) != 32 {
+			return nil, errors.New("verkle: keys and values must each be 32 bytes")
+		}
+		flat = append(flat, item...)
+	}
+	return flat, nil
+}
+
+// VerifyVerkleProof verifies a multiproof opening `keys` to `values` (nil
+// for an absence proof) against the Verkle trie commitment `root`. Keys
+// and values are each 32 bytes, per the Verkle trie spec.
+func VerifyVerkleProof(root [32]byte, keys, values [][]byte, proof *VerkleProof) (bool, error) {
+	if proof == nil {
+		return false, ErrEmptyProof
+	}
+	if len(keys) != len(values) {
+		return false, errors.New("verkle: number of keys must match number of values")
+	}
+	if len(keys) == 0 {
+		return false, errors.New("verkle: no keys given")
+	}
+
+	encoded, err := SerializeProof(proof)
+	if err != nil {
+		return false, err
+	}
+	flatKeys, err := flattenFixed32(keys)
+	if err != nil {
+		return false, err
+	}
+	flatValues, err := flattenFixed32(values)
+	if err != nil {
+		return false, err
+	}
+
+	status := C.ctt_eth_verkle_ipa_verify_multiproof(
+		(*C.byte)(unsafe.Pointer(&root)),
+		(*C.byte)(unsafe.Pointer(&flatKeys[0])),
+		(C.size_t)(len(keys)),
+		(*C.byte)(unsafe.Pointer(&flatValues[0])),
+		(*C.byte)(unsafe.Pointer(&encoded[0])),
+		(C.size_t)(len(encoded)),
+	)
+	if status != C.cttEthVerkleIpa_Success {
+		if status == C.cttEthVerkleIpa_VerificationFailure {
+			return false, nil
+		}
+		return false, errors.New(
+			C.GoString(C.ctt_eth_verkle_ipa_status_to_string(status)),
+		)
+	}
+	return true, nil
+}
@@ -0,0 +1,217 @@
+/** Constantine
+ *  Copyright (c) 2018-2019    Status Research & Development GmbH
+ *  Copyright (c) 2020-Present Mamy André-Ratsimbazafy
+ *  Licensed and distributed under either of
+ *    * MIT license (license terms in the root directory or at http://opensource.org/licenses/MIT).
+ *    * Apache v2 license (license terms in the root directory or at http://www.apache.org/licenses/LICENSE-2.0).
+ *  at your option. This file may not be copied, modified, or distributed except according to those terms.
+ */
+
+package verkle
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func randomProof(r *rand.Rand, nStems, nDepths, nCommitments int) *VerkleProof {
+	proof := &VerkleProof{
+		OtherStems:            make([][31]byte, nStems),
+		DepthExtensionPresent: make([]byte, nDepths),
+		CommitmentsByPath:     make([][32]byte, nCommitments),
+	}
+	for i := range proof.OtherStems {
+		r.Read(proof.OtherStems[i][:])
+	}
+	r.Read(proof.DepthExtensionPresent)
+	for i := range proof.CommitmentsByPath {
+		r.Read(proof.CommitmentsByPath[i][:])
+	}
+	r.Read(proof.D[:])
+	for i := range proof.IPAProof.CL {
+		r.Read(proof.IPAProof.CL[i][:])
+	}
+	for i := range proof.IPAProof.CR {
+		r.Read(proof.IPAProof.CR[i][:])
+	}
+	r.Read(proof.IPAProof.FinalEvaluation[:])
+	return proof
+}
+
+func TestSerializeProofRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+
+	for _, sizes := range [][3]int{{0, 0, 0}, {1, 31, 1}, {4, 120, 16}} {
+		proof := randomProof(r, sizes[0], sizes[1], sizes[2])
+
+		encoded, err := SerializeProof(proof)
+		require.NoError(t, err)
+
+		decoded, err := DeserializeProof(encoded)
+		require.NoError(t, err)
+
+		require.Equal(t, proof, decoded)
+	}
+}
+
+func TestDeserializeProofTruncated(t *testing.T) {
+	_, err := DeserializeProof([]byte{0x01, 0x00})
+	require.ErrorIs(t, err, ErrTruncatedProof)
+
+	proof := randomProof(rand.New(rand.NewSource(1)), 2, 4, 2)
+	encoded, err := SerializeProof(proof)
+	require.NoError(t, err)
+
+	for i := 1; i < len(encoded); i *= 2 {
+		_, err := DeserializeProof(encoded[:i])
+		require.Error(t, err)
+	}
+}
+
+func FuzzSerializeProofRoundTrip(f *testing.F) {
+	f.Add(int64(1), 0, 0, 0)
+	f.Add(int64(2), 3, 16, 5)
+	f.Fuzz(func(t *testing.T, seed int64, nStems, nDepths, nCommitments int) {
+		if nStems < 0 || nStems > 32 || nDepths < 0 || nDepths > 256 || nCommitments < 0 || nCommitments > 32 {
+			t.Skip("out of a reasonable fuzzing range")
+		}
+		r := rand.New(rand.NewSource(seed))
+		proof := randomProof(r, nStems, nDepths, nCommitments)
+
+		encoded, err := SerializeProof(proof)
+		require.NoError(t, err)
+
+		decoded, err := DeserializeProof(encoded)
+		require.NoError(t, err)
+		require.Equal(t, proof, decoded)
+	})
+}
+
+func TestFlattenFixed32AcceptsNilForAbsence(t *testing.T) {
+	value := make([]byte, 32)
+	value[0] = 0xAB
+
+	flat, err := flattenFixed32([][]byte{nil, value, nil})
+	require.NoError(t, err)
+	require.Len(t, flat, 3*32)
+	require.Equal(t, make([]byte, 32), flat[:32])
+	require.Equal(t, value, flat[32:64])
+	require.Equal(t, make([]byte, 32), flat[64:])
+
+	_, err = flattenFixed32([][]byte{{1, 2, 3}})
+	require.Error(t, err)
+}
+
+// JSON test vectors for VerifyVerkleProof
+// -----------------------------------------------------
+//
+// Mirrors the JSON test-vector harness the EIP-2537 precompile tests use
+// in constantine_test.go (TestPairingCheckBls/TestFailPairingCheckBls):
+// each fixture file is a JSON array of cases glob-matched from a test
+// vectors directory, and the directory (verify_multiproof or
+// fail_verify_multiproof) tells the test which outcome to expect from
+// VerifyVerkleProof.
+var (
+	testDirVerkle                = "../../tests/verkle"
+	verify_multiproof_tests      = filepath.Join(testDirVerkle, "verify_multiproof/*.json")
+	fail_verify_multiproof_tests = filepath.Join(testDirVerkle, "fail_verify_multiproof/*.json")
+)
+
+// verkleProofTest is one JSON fixture case for VerifyVerkleProof: Root,
+// Keys, and Values are hex strings (a Values entry is "" to signal the
+// absence-proof case), Proof is the hex-encoded SerializeProof output,
+// and Valid is the fixture's expected verification result. runVerkleVectors
+// takes the expected result from the fixture directory and does not read
+// Valid.
+type verkleProofTest struct {
+	Name   string
+	Root   string
+	Keys   []string
+	Values []string
+	Proof  string
+	Valid  bool
+}
+
+func loadVerkleVectors(fname string) (result []verkleProofTest, status bool) {
+	testFile, err := os.Open(fname)
+	if err != nil {
+		return nil, false
+	}
+	defer testFile.Close()
+
+	if err := json.NewDecoder(testFile).Decode(&result); err != nil {
+		return nil, false
+	}
+	return result, true
+}
+
+func parseVerkleVector(vec verkleProofTest) (root [32]byte, keys, values [][]byte, proof *VerkleProof, err error) {
+	rootBytes, err := hex.DecodeString(vec.Root)
+	if err != nil {
+		return root, nil, nil, nil, err
+	}
+	copy(root[:], rootBytes)
+
+	keys = make([][]byte, len(vec.Keys))
+	for i, k := range vec.Keys {
+		if keys[i], err = hex.DecodeString(k); err != nil {
+			return root, nil, nil, nil, err
+		}
+	}
+
+	values = make([][]byte, len(vec.Values))
+	for i, v := range vec.Values {
+		if v == "" {
+			continue // absence proof: nil value
+		}
+		if values[i], err = hex.DecodeString(v); err != nil {
+			return root, nil, nil, nil, err
+		}
+	}
+
+	proofBytes, err := hex.DecodeString(vec.Proof)
+	if err != nil {
+		return root, nil, nil, nil, err
+	}
+	proof, err = DeserializeProof(proofBytes)
+	return root, keys, values, proof, err
+}
+
+func runVerkleVectors(t *testing.T, testPath string, wantValid bool) {
+	tests, _ := filepath.Glob(testPath)
+	if len(tests) == 0 {
+		t.Skip("no Verkle multiproof test vectors available in this checkout")
+	}
+	for _, testPath := range tests {
+		t.Run(testPath, func(t *testing.T) {
+			vectors, pStatus := loadVerkleVectors(testPath)
+			require.True(t, pStatus)
+			for _, vec := range vectors {
+				root, keys, values, proof, err := parseVerkleVector(vec)
+				require.NoError(t, err)
+
+				valid, err := VerifyVerkleProof(root, keys, values, proof)
+				if wantValid {
+					require.NoError(t, err)
+					require.True(t, valid)
+				} else {
+					require.True(t, err != nil || !valid)
+				}
+			}
+		})
+	}
+}
+
+func TestVerifyVerkleProof(t *testing.T) {
+	runVerkleVectors(t, verify_multiproof_tests, true)
+}
+
+func TestVerifyVerkleProofFail(t *testing.T) {
+	runVerkleVectors(t, fail_verify_multiproof_tests, false)
+}